@@ -0,0 +1,40 @@
+package config
+
+import "time"
+
+// clock abstracts the passing of time, so that Watch can be tested
+// without real sleeps.
+type clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// NewTimer creates a timer that fires once after d
+	NewTimer(d time.Duration) timer
+}
+
+// timer is a single-shot timer created by a clock.
+type timer interface {
+	// C returns the channel on which the time is delivered when the timer fires
+	C() <-chan time.Time
+	// Stop prevents the timer from firing
+	Stop() bool
+}
+
+// realClock implements clock with the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) timer {
+	return realTimer{Timer: time.NewTimer(d)}
+}
+
+// realTimer implements timer with time.Timer.
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
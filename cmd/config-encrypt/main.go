@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-leo/config/encrypt"
+	"github.com/go-leo/config/encrypt/aesgcm"
+)
+
+// config-encrypt encrypts a value with an AES-GCM key file and prints it as
+// ENC[...], ready to paste into a configuration file.
+//
+//	config-encrypt -genkey -key config.key
+//	config-encrypt -key config.key 's3cr3t'
+//	echo 's3cr3t' | config-encrypt -key config.key
+func main() {
+	keyFile := flag.String("key", "", "path of the key file")
+	genKey := flag.Bool("genkey", false, "generate a new key file instead of encrypting")
+	decrypt := flag.Bool("d", false, "decrypt an ENC[...] value instead of encrypting")
+	flag.Parse()
+	if *keyFile == "" {
+		fmt.Fprintln(os.Stderr, "config-encrypt: -key is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*keyFile, *genKey, *decrypt, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "config-encrypt:", err)
+		os.Exit(1)
+	}
+}
+
+func run(keyFile string, genKey bool, decrypt bool, args []string) error {
+	if genKey {
+		if _, err := os.Stat(keyFile); err == nil {
+			return fmt.Errorf("key file %s already exists", keyFile)
+		}
+		key, err := aesgcm.GenerateKey()
+		if err != nil {
+			return err
+		}
+		return aesgcm.WriteKeyFile(keyFile, key)
+	}
+
+	c, err := aesgcm.NewFromFile(keyFile)
+	if err != nil {
+		return err
+	}
+	value, err := readValue(args)
+	if err != nil {
+		return err
+	}
+	if decrypt {
+		ciphertext, ok := encrypt.Parse(value)
+		if !ok {
+			return fmt.Errorf("value is not of the form %s...%s", encrypt.Prefix, encrypt.Suffix)
+		}
+		plaintext, err := c.Decrypt(ciphertext)
+		if err != nil {
+			return err
+		}
+		fmt.Println(plaintext)
+		return nil
+	}
+	ciphertext, err := c.Encrypt(value)
+	if err != nil {
+		return err
+	}
+	fmt.Println(encrypt.Format(ciphertext))
+	return nil
+}
+
+// readValue returns the value given as argument, or the first line of stdin.
+func readValue(args []string) (string, error) {
+	if len(args) > 0 {
+		return strings.Join(args, " "), nil
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("no value to encrypt: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -1,10 +1,14 @@
 package config
 
 import (
+	"fmt"
+
+	"github.com/go-leo/config/defaults"
 	"github.com/go-leo/config/proto/leo/config"
 	"golang.org/x/exp/slices"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 type Generator struct {
@@ -34,63 +38,56 @@ func (f *Generator) Generate() {
 	messages := f.EnabledMessage()
 
 	for _, message := range messages {
-		g.P("var (")
-		g.P(f.GlobalConfig(message), " = &", message.GoIdent, "{}")
-		g.P(f.GlobalConfigMutex(message), " ", RWMutex)
-		g.P(")")
+		if err := f.CheckDefaults(message); err != nil {
+			f.Plugin.Error(err)
+			return
+		}
+
+		g.P("func ", f.DefaultConfig(message), "() *", message.GoIdent, " {")
+		g.P("conf, err := ", Default, "[*", message.GoIdent, "]()")
+		g.P("if err != nil {")
+		g.P("panic(err)")
+		g.P("}")
+		g.P("return conf")
+		g.P("}")
+		g.P()
+
+		// The defaults are applied in init, since the message type is only
+		// registered by the init of the .pb.go file.
+		g.P("var ", f.GlobalConfig(message), " = ", NewStore, "[*", message.GoIdent, "](&", message.GoIdent, "{})")
+		g.P()
+
+		g.P("func init() {")
+		g.P(f.GlobalConfig(message), ".Set(", f.DefaultConfig(message), "())")
+		g.P("}")
+		g.P()
+
+		g.P("func ", f.ConfigStore(message), "() *", Store, "[*", message.GoIdent, "] {")
+		g.P("return ", f.GlobalConfig(message))
+		g.P("}")
 		g.P()
 
 		g.P("func ", f.GetConfig(message), "() *", message.GoIdent, " {")
-		g.P(f.GlobalConfigMutex(message), ".RLock()")
-		g.P("cloned := ", Clone, "(", f.GlobalConfig(message), ")")
-		g.P(f.GlobalConfigMutex(message), ".RUnlock()")
-		g.P("return ", "cloned.(*", message.GoIdent, ")")
+		g.P("return ", Clone, "(", f.GlobalConfig(message), ".Get()).(*", message.GoIdent, ")")
 		g.P("}")
 		g.P()
 
 		g.P("func ", f.SetConfig(message), "(conf *", message.GoIdent, ") {")
-		g.P("cloned := ", Clone, "(conf).(*", message.GoIdent, ")")
-		g.P(f.GlobalConfigMutex(message), ".Lock()")
-		g.P(f.GlobalConfig(message), " = cloned")
-		g.P(f.GlobalConfigMutex(message), ".Unlock()")
+		g.P(f.GlobalConfig(message), ".Set(", Clone, "(conf).(*", message.GoIdent, "))")
 		g.P("}")
 		g.P()
 
-		g.P("func ", f.LoadConfig(message), "(ctx ", Context, ", resources ...", Resource, ") error {")
-		g.P("conf, err := ", Load, "[*", message.GoIdent, "](ctx, resources...)")
-		g.P("if err != nil {")
-		g.P("return err")
-		g.P("}")
-		g.P(f.SetConfig(message), "(conf)")
-		g.P("return nil")
+		g.P("func ", f.LoadConfig(message), "(ctx ", Context, ", resources []", Resource, ", opts ...", Option, ") error {")
+		g.P("return ", f.GlobalConfig(message), ".Load(ctx, resources, opts...)")
 		g.P("}")
 		g.P()
 
-		g.P("func ", f.WatchConfig(message), "(ctx ", Context, ", resources ...", Resource, ") (<-chan struct{}, func(", Context, ")error, error) {")
-		g.P("notifyC := make(chan *", message.GoIdent, ")")
-		g.P("errC := make(chan error)")
-		g.P("stop, err := ", Watch, "(ctx, notifyC, errC, resources...)")
+		g.P("func ", f.WatchConfig(message), "(ctx ", Context, ", resources []", Resource, ", opts ...", Option, ") (<-chan ", Event, "[*", message.GoIdent, "], <-chan error, func(", Context, ")error, error) {")
+		g.P("changedC, errC, err := ", f.GlobalConfig(message), ".Watch(ctx, resources, opts...)")
 		g.P("if err != nil {")
-		g.P("return nil, nil, err")
-		g.P("}")
-		g.P("changedC := make(chan struct{}, 1)")
-		g.P("go func() {")
-		g.P("for {")
-		g.P("select {")
-		g.P("case <-ctx.Done():")
-		g.P("return")
-		g.P("case conf := <-notifyC:")
-		g.P(f.SetConfig(message), "(conf)")
-		g.P("select {")
-		g.P("case <-ctx.Done():")
-		g.P("return")
-		g.P("case changedC <- struct{}{}:")
-		g.P("default:")
-		g.P("}")
+		g.P("return nil, nil, nil, err")
 		g.P("}")
-		g.P("}")
-		g.P("}()")
-		g.P("return changedC, stop, nil")
+		g.P("return changedC, errC, ", f.GlobalConfig(message), ".Close, nil")
 		g.P("}")
 		g.P()
 
@@ -101,18 +98,52 @@ func (f *Generator) Generate() {
 		// g.P("return ", f.WatchConfig(message), "(ctx, opts...)")
 		// g.P("}")
 	}
+
+	for _, message := range f.AllMessages(f.File.Messages) {
+		if message.Desc.IsMapEntry() {
+			continue
+		}
+		g.P("// Redacted returns a copy of x with the sensitive fields masked, for logging.")
+		g.P("func (x *", message.GoIdent, ") Redacted() *", message.GoIdent, " {")
+		g.P("return ", Redact, "(x)")
+		g.P("}")
+		g.P()
+	}
+}
+
+// AllMessages returns messages and their nested messages.
+func (f *Generator) AllMessages(messages []*protogen.Message) []*protogen.Message {
+	var all []*protogen.Message
+	for _, message := range messages {
+		all = append(all, message)
+		all = append(all, f.AllMessages(message.Messages)...)
+	}
+	return all
+}
+
+// CheckDefaults reports default values declared on the fields of message
+// that cannot be converted to the type of their field.
+func (f *Generator) CheckDefaults(message *protogen.Message) error {
+	if err := defaults.Unmarshal(dynamicpb.NewMessage(message.Desc)); err != nil {
+		return fmt.Errorf("protoc-gen-config: invalid default value in %s: %w", message.Desc.FullName(), err)
+	}
+	return nil
+}
+
+func (f *Generator) DefaultConfig(message *protogen.Message) string {
+	return "Default" + f.Config(message)
 }
 
 func (f *Generator) GetFieldOption(message *protogen.Message, field *protogen.Field) string {
 	return "Get" + message.GoIdent.GoName + field.GoName + "Options"
 }
 
-func (f *Generator) GlobalConfigMutex(message *protogen.Message) string {
-	return f.GlobalConfig(message) + "Mutex"
+func (f *Generator) GlobalConfig(message *protogen.Message) string {
+	return "_" + f.ConfigStore(message)
 }
 
-func (f *Generator) GlobalConfig(message *protogen.Message) string {
-	return "_" + f.Config(message)
+func (f *Generator) ConfigStore(message *protogen.Message) string {
+	return f.Config(message) + "Store"
 }
 
 func (f *Generator) GetConfig(message *protogen.Message) string {
@@ -161,11 +192,6 @@ func findFunc[E any](s []E, f func(E) bool) (E, bool) {
 	return e, false
 }
 
-var (
-	syncPackage = protogen.GoImportPath("sync")
-	RWMutex     = syncPackage.Ident("RWMutex")
-)
-
 var (
 	protoPackage = protogen.GoImportPath("google.golang.org/protobuf/proto")
 	Clone        = protoPackage.Ident("Clone")
@@ -180,6 +206,12 @@ var (
 	configxPackage = protogen.GoImportPath("github.com/go-leo/config")
 	Load           = configxPackage.Ident("Load")
 	Watch          = configxPackage.Ident("Watch")
+	Option         = configxPackage.Ident("Option")
+	Event          = configxPackage.Ident("Event")
+	Store          = configxPackage.Ident("Store")
+	NewStore       = configxPackage.Ident("NewStore")
+	Default        = configxPackage.Ident("Default")
+	Redact         = configxPackage.Ident("Redact")
 )
 
 var (
@@ -0,0 +1,352 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-leo/config/merge"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// coerce returns a copy of value adapted to desc. Keys naming a field by its
+// JSON name are renamed to the proto name, so that resources address the same
+// fields by the same keys when merged. The values of loosely typed resources such as environment
+// variables, see resource.Loose, are adapted so that they can fill typed fields:
+//   - keys are matched to fields ignoring case
+//   - keys that match no field are split on "_" to reach nested fields,
+//     e.g. redis_db sets redis.db and servers_0_host sets servers[0].host
+//   - strings are converted to bools, numbers and enum numbers
+//   - structs with index keys and comma separated strings fill repeated fields
+//
+// The objects of resources writing a list of one object as the object itself,
+// see resource.Blocks, fill repeated message fields as lists of one item.
+// Keys and values that cannot be adapted are kept as they are.
+func coerce(desc protoreflect.MessageDescriptor, value *structpb.Struct, c coercion) *structpb.Struct {
+	value = proto.Clone(value).(*structpb.Struct)
+	coerceStruct(desc, value, c)
+	return value
+}
+
+// coercion selects the adaptations coerce applies to the values of a resource.
+type coercion struct {
+	// loose adapts loosely typed keys and values, see resource.Loose
+	loose bool
+	// blocks reads an object as a list of one object, see resource.Blocks
+	blocks bool
+}
+
+// coerceStruct adapts the keys and values of the message value to desc, in place.
+func coerceStruct(desc protoreflect.MessageDescriptor, value *structpb.Struct, c coercion) {
+	if value == nil || isWellKnown(desc) {
+		return
+	}
+	keys := make([]string, 0, len(value.GetFields()))
+	for key := range value.GetFields() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fd, rest := matchField(desc, key, c.loose)
+		if fd == nil {
+			continue
+		}
+		name := string(fd.Name())
+		if rest == "" && key == name {
+			continue
+		}
+		field := value.GetFields()[key]
+		delete(value.GetFields(), key)
+		// keys that already name the field exactly take precedence
+		if rest == "" {
+			if _, ok := value.GetFields()[name]; !ok {
+				value.GetFields()[name] = field
+			}
+			continue
+		}
+		// move the value beneath the field it addresses, e.g. redis_db to redis.db
+		nested := value.GetFields()[name]
+		if nested.GetStructValue() == nil {
+			nested = structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{}})
+			value.GetFields()[name] = nested
+		}
+		if _, ok := nested.GetStructValue().GetFields()[rest]; !ok {
+			nested.GetStructValue().GetFields()[rest] = field
+		}
+	}
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if field, ok := value.GetFields()[string(fd.Name())]; ok {
+			value.GetFields()[string(fd.Name())] = coerceField(fd, field, c)
+		}
+	}
+}
+
+// matchField finds the field addressed by key. rest is the remainder of key
+// below a singular or repeated message field or a map field, empty if key
+// names the field itself. Only loose keys may differ in case or address
+// nested fields.
+func matchField(desc protoreflect.MessageDescriptor, key string, loose bool) (protoreflect.FieldDescriptor, string) {
+	if fd := findField(desc, key); fd != nil || !loose {
+		return fd, ""
+	}
+	if fd := findFieldFold(desc, key); fd != nil {
+		return fd, ""
+	}
+	// longest prefix of "_" separated segments naming a nested field
+	for index := strings.LastIndexByte(key, '_'); index > 0; index = strings.LastIndexByte(key[:index], '_') {
+		fd := findFieldFold(desc, key[:index])
+		if fd == nil || (fd.Message() == nil && !fd.IsList()) {
+			continue
+		}
+		if rest := key[index+1:]; rest != "" {
+			return fd, rest
+		}
+	}
+	return nil, ""
+}
+
+// findField finds the field named key by proto name or JSON name.
+func findField(desc protoreflect.MessageDescriptor, key string) protoreflect.FieldDescriptor {
+	if fd := desc.Fields().ByName(protoreflect.Name(key)); fd != nil {
+		return fd
+	}
+	return desc.Fields().ByJSONName(key)
+}
+
+// findFieldFold finds the field named key by proto name or JSON name, ignoring case.
+func findFieldFold(desc protoreflect.MessageDescriptor, key string) protoreflect.FieldDescriptor {
+	if fd := findField(desc, key); fd != nil {
+		return fd
+	}
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if strings.EqualFold(string(fd.Name()), key) || strings.EqualFold(fd.JSONName(), key) {
+			return fd
+		}
+	}
+	return nil
+}
+
+// coerceField adapts value to the type of fd.
+func coerceField(fd protoreflect.FieldDescriptor, value *structpb.Value, c coercion) *structpb.Value {
+	if merge.IsNull(value) || merge.IsDelete(value) {
+		return value
+	}
+	switch {
+	case fd.IsMap():
+		entries := value.GetStructValue()
+		for key, entry := range entries.GetFields() {
+			entries.GetFields()[key] = coerceSingular(fd.MapValue(), entry, c)
+		}
+		return value
+	case fd.IsList():
+		list := toList(fd, value, c)
+		if list == nil {
+			return value
+		}
+		for i, item := range list.GetValues() {
+			list.GetValues()[i] = coerceSingular(fd, item, c)
+		}
+		return structpb.NewListValue(list)
+	default:
+		return coerceSingular(fd, value, c)
+	}
+}
+
+// toList converts value into the items of the repeated field fd: a list is
+// kept and, for blocks, another value is the single item, e.g. one HCL block
+// or one XML element. When loose, a struct whose keys are all indexes is
+// ordered by index and a string is split on commas for repeated scalars.
+// It returns nil otherwise.
+func toList(fd protoreflect.FieldDescriptor, value *structpb.Value, c coercion) *structpb.ListValue {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_ListValue:
+		return kind.ListValue
+	case *structpb.Value_StructValue:
+		if c.blocks && fd.Message() != nil && !(c.loose && isIndexed(kind.StructValue)) {
+			return &structpb.ListValue{Values: []*structpb.Value{value}}
+		}
+		if !c.loose {
+			return nil
+		}
+		keys := make([]string, 0, len(kind.StructValue.GetFields()))
+		for key := range kind.StructValue.GetFields() {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var indexes []int
+		items := make(map[int]*structpb.Value, len(keys))
+		for _, key := range keys {
+			item := kind.StructValue.GetFields()[key]
+			// 0 is the item itself, 0_host a key of the item
+			prefix, rest, nested := strings.Cut(key, "_")
+			index, err := strconv.Atoi(prefix)
+			if err != nil || index < 0 || (nested && fd.Message() == nil) {
+				return nil
+			}
+			if _, ok := items[index]; !ok {
+				indexes = append(indexes, index)
+			}
+			if !nested {
+				items[index] = item
+				continue
+			}
+			if items[index].GetStructValue() == nil {
+				items[index] = structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{}})
+			}
+			items[index].GetStructValue().GetFields()[rest] = item
+		}
+		sort.Ints(indexes)
+		list := &structpb.ListValue{}
+		for _, index := range indexes {
+			list.Values = append(list.Values, items[index])
+		}
+		return list
+	case *structpb.Value_StringValue:
+		if fd.Message() != nil || !c.loose {
+			return singleItem(fd, value, c)
+		}
+		list := &structpb.ListValue{}
+		if kind.StringValue == "" {
+			return list
+		}
+		for _, item := range strings.Split(kind.StringValue, ",") {
+			list.Values = append(list.Values, structpb.NewStringValue(strings.TrimSpace(item)))
+		}
+		return list
+	default:
+		return singleItem(fd, value, c)
+	}
+}
+
+// singleItem returns the scalar value as the single item of the repeated
+// scalar field fd for blocks, nil otherwise.
+func singleItem(fd protoreflect.FieldDescriptor, value *structpb.Value, c coercion) *structpb.ListValue {
+	if !c.blocks || fd.Message() != nil {
+		return nil
+	}
+	return &structpb.ListValue{Values: []*structpb.Value{value}}
+}
+
+// isIndexed reports whether value has keys and every key starts with a list
+// index, such as 0 or 0_host.
+func isIndexed(value *structpb.Struct) bool {
+	if len(value.GetFields()) == 0 {
+		return false
+	}
+	for key := range value.GetFields() {
+		prefix, _, _ := strings.Cut(key, "_")
+		if index, err := strconv.Atoi(prefix); err != nil || index < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// coerceSingular adapts a single value to the type of fd.
+func coerceSingular(fd protoreflect.FieldDescriptor, value *structpb.Value, c coercion) *structpb.Value {
+	if fd.Message() != nil {
+		coerceStruct(fd.Message(), value.GetStructValue(), c)
+		return value
+	}
+	if !c.loose {
+		return value
+	}
+	return convertString(fd, value)
+}
+
+// convertString converts a string value to the scalar type of fd,
+// other values and strings that cannot be converted are kept.
+func convertString(fd protoreflect.FieldDescriptor, value *structpb.Value) *structpb.Value {
+	s, ok := value.GetKind().(*structpb.Value_StringValue)
+	if !ok {
+		return value
+	}
+	text := strings.TrimSpace(s.StringValue)
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if b, err := strconv.ParseBool(text); err == nil {
+			return structpb.NewBoolValue(b)
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.FloatKind, protoreflect.DoubleKind:
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return structpb.NewNumberValue(f)
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson accepts 64-bit integers as strings, which keeps their precision
+		return structpb.NewStringValue(text)
+	case protoreflect.EnumKind:
+		if n, err := strconv.Atoi(text); err == nil {
+			return structpb.NewNumberValue(float64(n))
+		}
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(strings.ToUpper(text))); ev != nil {
+			return structpb.NewStringValue(string(ev.Name()))
+		}
+	}
+	return value
+}
+
+// coerceExpanded returns a copy of value in which the strings that differ
+// from merged, that is produced by interpolation, decryption or secrets, are
+// converted to the scalar types of their fields. Other values are kept.
+func coerceExpanded(desc protoreflect.MessageDescriptor, value *structpb.Struct, merged *structpb.Struct) *structpb.Struct {
+	value = proto.Clone(value).(*structpb.Struct)
+	expandStruct(desc, value, merged)
+	return value
+}
+
+// expandStruct converts the expanded strings of the message value, in place.
+func expandStruct(desc protoreflect.MessageDescriptor, value *structpb.Struct, merged *structpb.Struct) {
+	if value == nil || isWellKnown(desc) {
+		return
+	}
+	for key, field := range value.GetFields() {
+		fd := findField(desc, key)
+		if fd == nil {
+			continue
+		}
+		mergedField := merged.GetFields()[key]
+		switch {
+		case fd.IsMap():
+			for key, entry := range field.GetStructValue().GetFields() {
+				field.GetStructValue().GetFields()[key] = expandSingular(fd.MapValue(), entry, mergedField.GetStructValue().GetFields()[key])
+			}
+		case fd.IsList():
+			mergedItems := mergedField.GetListValue().GetValues()
+			for index, item := range field.GetListValue().GetValues() {
+				var mergedItem *structpb.Value
+				if index < len(mergedItems) {
+					mergedItem = mergedItems[index]
+				}
+				field.GetListValue().GetValues()[index] = expandSingular(fd, item, mergedItem)
+			}
+		default:
+			value.GetFields()[key] = expandSingular(fd, field, mergedField)
+		}
+	}
+}
+
+// expandSingular converts a single value to the type of fd if it was expanded.
+func expandSingular(fd protoreflect.FieldDescriptor, value *structpb.Value, merged *structpb.Value) *structpb.Value {
+	if fd.Message() != nil {
+		expandStruct(fd.Message(), value.GetStructValue(), merged.GetStructValue())
+		return value
+	}
+	if proto.Equal(value, merged) {
+		return value
+	}
+	return convertString(fd, value)
+}
+
+// isWellKnown reports whether desc is a well-known type with a special JSON form.
+func isWellKnown(desc protoreflect.MessageDescriptor) bool {
+	return desc.ParentFile().Package() == "google.protobuf"
+}
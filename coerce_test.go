@@ -0,0 +1,177 @@
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-leo/config/format/hcl"
+	"github.com/go-leo/config/format/xml"
+	"github.com/go-leo/config/resource"
+	"github.com/go-leo/config/test"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestLoadCoercion(t *testing.T) {
+	t.Run("Scalars", func(t *testing.T) {
+		env, _ := structpb.NewStruct(map[string]interface{}{
+			"ADDR":             "10.0.0.1",
+			"PORT":             "9090",
+			"debug":            "false",
+			"TAGS":             "x, y",
+			"timeout":          "2s",
+			"limits_max_conns": "10",
+			"upstream_addr":    "10.0.0.2",
+			"upstream_WEIGHT":  "3",
+		})
+		conf, err := Load[*test.Server](context.Background(), []resource.Resource{&mockLoadResource{value: env, loose: true}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := &test.Server{
+			Addr:     "10.0.0.1",
+			Port:     9090,
+			Tags:     []string{"x", "y"},
+			Timeout:  durationpb.New(2 * time.Second),
+			Limits:   &test.Limits{MaxConns: 10},
+			Upstream: &test.Upstream{Addr: "10.0.0.2", Weight: 3},
+			Plugins:  []string{"a", "b"},
+		}
+		if !proto.Equal(expected, conf) {
+			t.Errorf("Expected %v, got %v", expected, conf)
+		}
+	})
+
+	t.Run("ListsAndMaps", func(t *testing.T) {
+		env, _ := structpb.NewStruct(map[string]interface{}{
+			"upstreams_1_name":   "b",
+			"upstreams_1_weight": "5",
+			"upstreams_0_name":   "a",
+			"upstreams_0_tags":   "x,y",
+			"ROUTES":             map[string]interface{}{"api": map[string]interface{}{"ADDR": "10.0.0.1", "weight": "2"}},
+			"hosts":              map[string]interface{}{"1": "h1", "0": "h0"},
+		})
+		conf, err := Load[*test.Gateway](context.Background(), []resource.Resource{&mockLoadResource{value: env, loose: true}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := &test.Gateway{
+			Upstreams: []*test.Upstream{{Name: "a", Tags: []string{"x", "y"}}, {Name: "b", Weight: 5}},
+			Routes:    map[string]*test.Upstream{"api": {Addr: "10.0.0.1", Weight: 2}},
+			Hosts:     []string{"h0", "h1"},
+		}
+		if !proto.Equal(expected, conf) {
+			t.Errorf("Expected %v, got %v", expected, conf)
+		}
+	})
+
+	t.Run("SingleItem", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			input    string
+			expected []*test.Upstream
+		}{
+			{name: "Block", input: "upstreams {\n  name = \"a\"\n  weight = 2\n}", expected: []*test.Upstream{{Name: "a", Weight: 2}}},
+			{name: "EmptyBlock", input: "upstreams {\n}", expected: []*test.Upstream{{}}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				value, err := hcl.Hcl{}.Parse([]byte(tt.input))
+				if err != nil {
+					t.Fatal(err)
+				}
+				conf, err := Load[*test.Gateway](context.Background(), []resource.Resource{&mockLoadResource{value: value, blocks: true}})
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if expected := (&test.Gateway{Upstreams: tt.expected}); !proto.Equal(expected, conf) {
+					t.Errorf("Expected %v, got %v", expected, conf)
+				}
+			})
+		}
+
+		// only formats writing single blocks read an object as a list
+		value, _ := structpb.NewStruct(map[string]interface{}{"upstreams": map[string]interface{}{"name": "a"}})
+		if _, err := Load[*test.Gateway](context.Background(), []resource.Resource{&mockLoadResource{value: value, loose: true}}); err == nil {
+			t.Error("Expected error for an object in a repeated field")
+		}
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		value, err := xml.Xml{}.Parse([]byte(`<gateway><upstreams name="a" weight="2"><tags>x</tags></upstreams><upstreams name="b"/><hosts>h0</hosts><hosts>h1</hosts></gateway>`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		conf, err := Load[*test.Gateway](context.Background(), []resource.Resource{&mockLoadResource{value: value, loose: true, blocks: true}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := &test.Gateway{
+			Upstreams: []*test.Upstream{{Name: "a", Weight: 2, Tags: []string{"x"}}, {Name: "b"}},
+			Hosts:     []string{"h0", "h1"},
+		}
+		if !proto.Equal(expected, conf) {
+			t.Errorf("Expected %v, got %v", expected, conf)
+		}
+	})
+
+	t.Run("XMLSingleElements", func(t *testing.T) {
+		value, err := xml.Xml{}.Parse([]byte(`<gw><upstreams><name>a</name></upstreams><tags>t</tags></gw>`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// one element of a repeated field is a list of one item
+		conf, err := Load[*test.Gateway](context.Background(), []resource.Resource{&mockLoadResource{value: value, blocks: xml.Xml{}.Blocks()}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := &test.Gateway{Upstreams: []*test.Upstream{{Name: "a"}}, Tags: []string{"t"}}
+		if !proto.Equal(expected, conf) {
+			t.Errorf("Expected %v, got %v", expected, conf)
+		}
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		value, _ := structpb.NewStruct(map[string]interface{}{"maxConns": 10})
+		conf, err := Load[*test.Limits](context.Background(), []resource.Resource{&mockLoadResource{value: value}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if conf.GetMaxConns() != 10 {
+			t.Errorf("Expected max_conns 10 from the JSON name, got %v", conf)
+		}
+		// only loosely typed resources are matched ignoring case and converted
+		for _, value := range []map[string]interface{}{{"MAX_CONNS": 10}, {"server": map[string]interface{}{"debug": "true"}}, {"server_port": 80}} {
+			value, _ := structpb.NewStruct(value)
+			if _, err := Load[*test.Limits](context.Background(), []resource.Resource{&mockLoadResource{value: value}}); err == nil {
+				t.Errorf("Expected error for %v", value)
+			}
+		}
+	})
+
+	t.Run("Precedence", func(t *testing.T) {
+		file, _ := structpb.NewStruct(map[string]interface{}{"limits": map[string]interface{}{"max_conns": 1}, "port": 80})
+		env, _ := structpb.NewStruct(map[string]interface{}{"LIMITS_MAX_CONNS": "10"})
+		conf, err := Load[*test.Server](context.Background(), []resource.Resource{&mockLoadResource{value: file}, &mockLoadResource{value: env, loose: true}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if conf.GetLimits().GetMaxConns() != 10 || conf.GetPort() != 80 {
+			t.Errorf("Expected max_conns 10 from env and port 80 from file, got %v", conf)
+		}
+	})
+
+	t.Run("Interpolated", func(t *testing.T) {
+		value, _ := structpb.NewStruct(map[string]interface{}{"debug": "${DEBUG}", "port": "${PORT:8081}"})
+		lookupEnv := func(key string) (string, bool) { return "true", key == "DEBUG" }
+		conf, err := Load[*test.Server](context.Background(), []resource.Resource{&mockLoadResource{value: value}}, WithInterpolation(true), WithLookupEnv(lookupEnv))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !conf.GetDebug() || conf.GetPort() != 8081 {
+			t.Errorf("Expected debug and port 8081, got %v", conf)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-leo/config/encrypt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// decrypt returns a copy of value where every encrypted value, ENC[...],
+// is replaced by its plaintext. It fails if value holds encrypted values
+// and decrypter is nil.
+func decrypt(value *structpb.Struct, decrypter encrypt.Decrypter) (*structpb.Struct, error) {
+	value = proto.Clone(value).(*structpb.Struct)
+	err := replaceStrings("", structpb.NewStructValue(value), func(path string, s string) (string, error) {
+		ciphertext, ok := encrypt.Parse(s)
+		if !ok {
+			return s, nil
+		}
+		if decrypter == nil {
+			return "", fmt.Errorf("config: %s is encrypted but no decrypter is configured, see WithDecrypter", path)
+		}
+		plaintext, err := decrypter.Decrypt(ciphertext)
+		if err != nil {
+			return "", fmt.Errorf("config: failed to decrypt %s: %w", path, err)
+		}
+		return plaintext, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// replaceStrings replaces every string nested in value, found at path,
+// by the result of fn.
+func replaceStrings(path string, value *structpb.Value, fn func(path string, s string) (string, error)) error {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		s, err := fn(path, kind.StringValue)
+		if err != nil {
+			return err
+		}
+		kind.StringValue = s
+	case *structpb.Value_StructValue:
+		for key, field := range kind.StructValue.GetFields() {
+			if err := replaceStrings(joinPath(path, key), field, fn); err != nil {
+				return err
+			}
+		}
+	case *structpb.Value_ListValue:
+		for index, item := range kind.ListValue.GetValues() {
+			if err := replaceStrings(joinPath(path, strconv.Itoa(index)), item, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-leo/config/encrypt"
+	"github.com/go-leo/config/encrypt/aesgcm"
+	"github.com/go-leo/config/resource"
+	"github.com/go-leo/config/test"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestLoadEncrypted(t *testing.T) {
+	key, _ := aesgcm.GenerateKey()
+	c, err := aesgcm.New(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := c.Encrypt("s3cr3t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStruct, _ := structpb.NewStruct(map[string]interface{}{"field1": encrypt.Format(ciphertext), "field2": "plain"})
+	resources := []resource.Resource{&mockLoadResource{value: testStruct}}
+
+	result, err := Load[*test.Config](context.Background(), resources, WithDecrypter(c))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Field1 != "s3cr3t" || result.Field2 != "plain" {
+		t.Errorf("Expected 's3cr3t' and 'plain', got '%s' and '%s'", result.Field1, result.Field2)
+	}
+
+	if _, err := Load[*test.Config](context.Background(), resources); err == nil {
+		t.Error("Expected error without decrypter, got nil")
+	}
+	otherKey, _ := aesgcm.GenerateKey()
+	other, _ := aesgcm.New(otherKey)
+	if _, err := Load[*test.Config](context.Background(), resources, WithDecrypter(other)); err == nil {
+		t.Error("Expected error with the wrong key, got nil")
+	}
+}
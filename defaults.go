@@ -0,0 +1,72 @@
+package config
+
+import (
+	"github.com/go-leo/config/defaults"
+	"github.com/go-leo/config/merge"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DefaultSource is the source name of the default values in provenance.
+const DefaultSource = "default"
+
+// Defaults returns the default values declared with the (leo.config.default)
+// field option in desc and its nested messages, as a value that can be merged
+// with the values loaded from resources. See defaults.Value.
+func Defaults(desc protoreflect.MessageDescriptor) *structpb.Struct {
+	return defaults.Value(desc)
+}
+
+// Default returns a Config holding the default values declared in its proto.
+func Default[Config proto.Message]() (Config, error) {
+	var config Config
+	config = config.ProtoReflect().Type().New().Interface().(Config)
+	if err := UnmarshalDefaults(config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// UnmarshalDefaults sets the default values declared in the proto of msg.
+// It reports defaults that cannot be converted to the type of their field.
+func UnmarshalDefaults(msg proto.Message) error {
+	return defaults.Unmarshal(msg)
+}
+
+// fillDefaults sets the values of defaults that are missing in value, in
+// place. Keys set by a resource keep their value, explicit nulls included,
+// so defaults are never merged with the values of the resources, e.g. with
+// the APPEND strategy. The filled paths are recorded in provenance if not nil.
+func fillDefaults(value *structpb.Struct, defaults *structpb.Struct, path string, provenance merge.Provenance) {
+	if value.Fields == nil {
+		value.Fields = make(map[string]*structpb.Value, len(defaults.GetFields()))
+	}
+	for key, def := range defaults.GetFields() {
+		keyPath := joinPath(path, key)
+		field, ok := value.GetFields()[key]
+		if !ok {
+			value.Fields[key] = proto.Clone(def).(*structpb.Value)
+			recordDefaults(def, keyPath, provenance)
+			continue
+		}
+		if nested, nestedDefaults := field.GetStructValue(), def.GetStructValue(); nested != nil && nestedDefaults != nil {
+			fillDefaults(nested, nestedDefaults, keyPath, provenance)
+		}
+	}
+}
+
+// recordDefaults records DefaultSource as the origin of every leaf of the
+// default value def at path, if provenance is not nil.
+func recordDefaults(def *structpb.Value, path string, provenance merge.Provenance) {
+	if provenance == nil {
+		return
+	}
+	if nested := def.GetStructValue(); nested != nil {
+		for key, field := range nested.GetFields() {
+			recordDefaults(field, joinPath(path, key), provenance)
+		}
+		return
+	}
+	provenance[path] = &merge.Origin{Source: DefaultSource}
+}
@@ -0,0 +1,92 @@
+// Package defaults parses the default values declared with the
+// (leo.config.default) field option. It only depends on protobuf, so that
+// it can be shared by the config package and protoc-gen-config.
+package defaults
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/go-leo/config/proto/leo/config"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// cache caches the default values of every message, by full name.
+var cache sync.Map
+
+// Value returns the default values declared in desc and its nested messages,
+// as a value that can be merged with the values loaded from resources.
+// The returned value is shared and must not be modified.
+//
+// A default is written as the JSON form of the field, e.g. 8080, true or
+// ["a","b"]. For string fields, and when the default is not valid JSON,
+// the text itself is used, e.g. 127.0.0.1 or 1s.
+func Value(desc protoreflect.MessageDescriptor) *structpb.Struct {
+	if value, ok := cache.Load(desc.FullName()); ok {
+		return value.(*structpb.Struct)
+	}
+	value, _ := defaults(desc, map[protoreflect.FullName]bool{})
+	cache.Store(desc.FullName(), value)
+	return value
+}
+
+// Unmarshal sets the default values declared in the proto of msg.
+// It reports defaults that cannot be converted to the type of their field.
+func Unmarshal(msg proto.Message) error {
+	value := Value(msg.ProtoReflect().Descriptor())
+	if len(value.GetFields()) == 0 {
+		return nil
+	}
+	data, err := value.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(data, msg)
+}
+
+// defaults builds the default values of desc, visiting tracks the messages on
+// the current path so that recursive messages terminate. It returns false if
+// desc declares no defaults.
+func defaults(desc protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]bool) (*structpb.Struct, bool) {
+	value := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	if visiting[desc.FullName()] {
+		return value, false
+	}
+	visiting[desc.FullName()] = true
+	defer delete(visiting, desc.FullName())
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if proto.HasExtension(fd.Options(), config.E_Default) {
+			value.Fields[string(fd.Name())] = defaultValue(fd, proto.GetExtension(fd.Options(), config.E_Default).(string))
+			continue
+		}
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			continue
+		}
+		if nested, ok := defaults(fd.Message(), visiting); ok {
+			value.Fields[string(fd.Name())] = structpb.NewStructValue(nested)
+		}
+	}
+	return value, len(value.Fields) > 0
+}
+
+// defaultValue parses the default text of fd.
+func defaultValue(fd protoreflect.FieldDescriptor, text string) *structpb.Value {
+	if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+		return structpb.NewStringValue(text)
+	}
+	var v any
+	if err := json.Unmarshal([]byte(text), &v); err != nil {
+		return structpb.NewStringValue(text)
+	}
+	value, err := structpb.NewValue(v)
+	if err != nil {
+		return structpb.NewStringValue(text)
+	}
+	return value
+}
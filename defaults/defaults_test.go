@@ -0,0 +1,38 @@
+package defaults
+
+import (
+	"testing"
+
+	"github.com/go-leo/config/test"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestValue(t *testing.T) {
+	expected, _ := structpb.NewStruct(map[string]interface{}{
+		"addr":    "127.0.0.1",
+		"port":    8080,
+		"debug":   true,
+		"tags":    []interface{}{"a", "b"},
+		"timeout": "1s",
+		"limits":  map[string]interface{}{"max_conns": 100},
+		"plugins": []interface{}{"a", "b"},
+	})
+	value := Value((&test.Server{}).ProtoReflect().Descriptor())
+	if !proto.Equal(expected, value) {
+		t.Errorf("Expected %v, got %v", expected, value)
+	}
+	if value := Value((&test.Config{}).ProtoReflect().Descriptor()); len(value.GetFields()) != 0 {
+		t.Errorf("Expected no defaults, got %v", value)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	conf := &test.Endpoint{}
+	if err := Unmarshal(conf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if conf.GetPort() != 80 {
+		t.Errorf("Expected port 80, got %v", conf)
+	}
+}
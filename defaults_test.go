@@ -0,0 +1,89 @@
+package config
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-leo/config/merge"
+	"github.com/go-leo/config/resource"
+	"github.com/go-leo/config/test"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestDefault(t *testing.T) {
+	conf, err := Default[*test.Server]()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := &test.Server{
+		Addr:    "127.0.0.1",
+		Port:    8080,
+		Debug:   true,
+		Tags:    []string{"a", "b"},
+		Timeout: durationpb.New(time.Second),
+		Limits:  &test.Limits{MaxConns: 100},
+		Plugins: []string{"a", "b"},
+	}
+	if !proto.Equal(expected, conf) {
+		t.Errorf("Expected %v, got %v", expected, conf)
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	testStruct, _ := structpb.NewStruct(map[string]interface{}{
+		"port":   9090,
+		"limits": map[string]interface{}{"max_conns": 10},
+	})
+	resources := []resource.Resource{
+		&namedResource{mockLoadResource: mockLoadResource{value: testStruct}, name: "file:server.yaml"},
+	}
+	var provenance merge.Provenance
+	conf, err := Load[*test.Server](context.Background(), resources, WithProvenance(func(p merge.Provenance) {
+		provenance = p
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if conf.GetAddr() != "127.0.0.1" || conf.GetPort() != 9090 || conf.GetLimits().GetMaxConns() != 10 {
+		t.Errorf("Expected defaults to be overridden by resources, got %v", conf)
+	}
+	if origin := provenance["addr"]; origin == nil || origin.Source != DefaultSource {
+		t.Errorf("Expected addr from %s, got %v", DefaultSource, origin)
+	}
+	if origin := provenance["port"]; origin == nil || origin.Source != "file:server.yaml" {
+		t.Errorf("Expected port from file:server.yaml, got %v", origin)
+	}
+}
+
+func TestLoadDefaultsNotMerged(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []map[string]interface{}
+		expected []string
+	}{
+		{name: "Unset", values: []map[string]interface{}{{}}, expected: []string{"a", "b"}},
+		{name: "Replaced", values: []map[string]interface{}{{"plugins": []interface{}{"c"}}}, expected: []string{"c"}},
+		{name: "AppendedByResources", values: []map[string]interface{}{{"plugins": []interface{}{"c"}}, {"plugins": []interface{}{"d"}}}, expected: []string{"c", "d"}},
+		{name: "Null", values: []map[string]interface{}{{"plugins": nil}}, expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resources []resource.Resource
+			for _, value := range tt.values {
+				v, _ := structpb.NewStruct(value)
+				resources = append(resources, &mockLoadResource{value: v})
+			}
+			conf, err := Load[*test.Server](context.Background(), resources)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.expected, conf.GetPlugins()) {
+				t.Errorf("Expected plugins %v, got %v", tt.expected, conf.GetPlugins())
+			}
+		})
+	}
+}
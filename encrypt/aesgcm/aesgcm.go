@@ -0,0 +1,110 @@
+package aesgcm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeySize is the size of the keys generated by GenerateKey, selecting AES-256.
+const KeySize = 32
+
+// Cipher implements the encrypt.Encrypter and encrypt.Decrypter interfaces
+// with AES-GCM. Ciphertexts are the base64 encoding of the random nonce
+// followed by the sealed value.
+type Cipher struct {
+	// aead the AES-GCM cipher
+	aead cipher.AEAD
+}
+
+// Encrypt encrypts plaintext with a random nonce.
+//
+// Args:
+//
+// plaintext string: Value to encrypt
+//
+// Returns:
+//
+// string: Base64 encoded nonce and ciphertext
+// error: Error encountered while generating the nonce
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a ciphertext returned by Encrypt.
+//
+// Args:
+//
+// ciphertext string: Base64 encoded nonce and ciphertext
+//
+// Returns:
+//
+// string: Decrypted value
+// error: Error if the ciphertext is malformed or was not encrypted with this key
+func (c *Cipher) Decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("config: malformed ciphertext: %w", err)
+	}
+	if len(sealed) < c.aead.NonceSize() {
+		return "", errors.New("config: malformed ciphertext: too short")
+	}
+	nonce, sealed := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", fmt.Errorf("config: failed to decrypt: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// New creates a cipher from a 16, 24 or 32 bytes key, selecting AES-128,
+// AES-192 or AES-256.
+func New(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// NewFromFile creates a cipher from a key file holding the base64 encoded key,
+// as written by WriteKeyFile.
+func NewFromFile(filename string) (*Cipher, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("config: malformed key file %s: %w", filename, err)
+	}
+	return New(key)
+}
+
+// GenerateKey returns a new random AES-256 key.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// WriteKeyFile writes key base64 encoded to filename, readable by the owner only.
+func WriteKeyFile(filename string, key []byte) error {
+	return os.WriteFile(filename, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0o600)
+}
@@ -0,0 +1,49 @@
+package aesgcm
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCipher(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "config.key")
+	if err := WriteKeyFile(filename, key); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewFromFile(filename)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ciphertext, err := c.Encrypt("s3cr3t")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	other, _ := c.Encrypt("s3cr3t")
+	if ciphertext == other {
+		t.Error("Expected random nonces to produce different ciphertexts")
+	}
+	plaintext, err := c.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if plaintext != "s3cr3t" {
+		t.Errorf("Expected 's3cr3t', got %q", plaintext)
+	}
+
+	otherKey, _ := GenerateKey()
+	wrong, _ := New(otherKey)
+	for _, ciphertext := range []string{ciphertext, "not base64", "YWJj"} {
+		if _, err := wrong.Decrypt(ciphertext); err == nil {
+			t.Errorf("Expected error decrypting %q, got nil", ciphertext)
+		}
+	}
+
+	if _, err := New([]byte("short")); err == nil {
+		t.Error("Expected error for invalid key size, got nil")
+	}
+}
@@ -0,0 +1,68 @@
+package encrypt
+
+import (
+	"strings"
+)
+
+const (
+	// Prefix starts every encrypted value
+	Prefix = "ENC["
+	// Suffix ends every encrypted value
+	Suffix = "]"
+)
+
+// Encrypter interface defines the standard method for encrypting values
+type Encrypter interface {
+	// Encrypt encrypts a value
+	//
+	// Args:
+	//   plaintext (string): Value to encrypt
+	//
+	// Returns:
+	//   string: Ciphertext, without the ENC[...] wrapping
+	//   error: Error if encryption fails
+	Encrypt(plaintext string) (string, error)
+}
+
+// Decrypter interface defines the standard method for decrypting values
+type Decrypter interface {
+	// Decrypt decrypts a value
+	//
+	// Args:
+	//   ciphertext (string): Ciphertext, without the ENC[...] wrapping
+	//
+	// Returns:
+	//   string: Decrypted value
+	//   error: Error if decryption fails
+	Decrypt(ciphertext string) (string, error)
+}
+
+// Parse extracts the ciphertext of an encrypted value such as ENC[...]
+//
+// Args:
+//
+//	value (string): Configuration value
+//
+// Returns:
+//
+//	string: Ciphertext between the brackets
+//	bool: False if value is not an encrypted value
+func Parse(value string) (string, bool) {
+	if !strings.HasPrefix(value, Prefix) || !strings.HasSuffix(value, Suffix) || len(value) < len(Prefix)+len(Suffix) {
+		return "", false
+	}
+	return value[len(Prefix) : len(value)-len(Suffix)], true
+}
+
+// Format wraps a ciphertext into an encrypted value, ENC[ciphertext]
+//
+// Args:
+//
+//	ciphertext (string): Ciphertext returned by an Encrypter
+//
+// Returns:
+//
+//	string: Encrypted value to put in a configuration file
+func Format(ciphertext string) string {
+	return Prefix + ciphertext + Suffix
+}
@@ -0,0 +1,29 @@
+package encrypt
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value      string
+		ciphertext string
+		ok         bool
+	}{
+		{value: "ENC[abc=]", ciphertext: "abc=", ok: true},
+		{value: "ENC[]", ciphertext: "", ok: true},
+		{value: "ENC[abc"},
+		{value: "abc]"},
+		{value: "ENC]"},
+		{value: "password"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			ciphertext, ok := Parse(tt.value)
+			if ciphertext != tt.ciphertext || ok != tt.ok {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.ciphertext, tt.ok, ciphertext, ok)
+			}
+		})
+	}
+	if value := Format("abc="); value != "ENC[abc=]" {
+		t.Errorf("Expected 'ENC[abc=]', got %q", value)
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Event describes a configuration update delivered by Watch.
+type Event[Config proto.Message] struct {
+	// Old is the configuration before the update. For the first update of a Watch
+	// it is the configuration set by WithInitial, nil if none
+	Old Config
+	// New is the configuration after the update
+	New Config
+	// Changed lists the paths of the fields that differ between Old and New
+	Changed *fieldmaskpb.FieldMask
+}
+
+// HasChanged reports whether the field at path, or any field nested in it,
+// changed in this update. For example HasChanged("redis") is true when
+// redis.addr changed, and so is HasChanged("redis.addr") when redis was replaced.
+func (e Event[Config]) HasChanged(path string) bool {
+	for _, changed := range e.Changed.GetPaths() {
+		if changed == path || strings.HasPrefix(changed, path+".") || strings.HasPrefix(path, changed+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge combines e with the next update into a single event,
+// going from e.Old to next.New.
+func (e Event[Config]) Merge(next Event[Config]) Event[Config] {
+	return Event[Config]{Old: e.Old, New: next.New, Changed: Diff(e.Old, next.New)}
+}
+
+// String describes the update for logging, with sensitive fields redacted.
+func (e Event[Config]) String() string {
+	return fmt.Sprintf("changed %v: {%v} -> {%v}", e.Changed.GetPaths(), Redact(e.Old), Redact(e.New))
+}
+
+// Diff compares two messages of the same type and returns the paths of the
+// fields that differ, using proto field names joined by ".".
+// Singular message fields are compared field by field, repeated and map
+// fields are reported as a whole. A nil message is treated as empty.
+func Diff(old proto.Message, new proto.Message) *fieldmaskpb.FieldMask {
+	mask := &fieldmaskpb.FieldMask{}
+	diffMessage(mask, "", old.ProtoReflect(), new.ProtoReflect())
+	return mask
+}
+
+// diffMessage appends the paths of the fields that differ between x and y to mask.
+func diffMessage(mask *fieldmaskpb.FieldMask, prefix string, x protoreflect.Message, y protoreflect.Message) {
+	fields := y.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := string(fd.Name())
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		if !x.Has(fd) && !y.Has(fd) {
+			continue
+		}
+		if fd.Message() != nil && !fd.IsList() && !fd.IsMap() && x.Has(fd) && y.Has(fd) {
+			diffMessage(mask, path, x.Get(fd).Message(), y.Get(fd).Message())
+			continue
+		}
+		if x.Has(fd) != y.Has(fd) || !x.Get(fd).Equal(y.Get(fd)) {
+			mask.Paths = append(mask.Paths, path)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-leo/config/test"
+)
+
+func TestDiff(t *testing.T) {
+	base := &test.Gateway{
+		Upstreams: []*test.Upstream{{Name: "a", Addr: "10.0.0.1"}},
+		Tags:      []string{"base"},
+		Routes: map[string]*test.Upstream{
+			"/": {Name: "a"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		old      *test.Gateway
+		new      *test.Gateway
+		expected []string
+	}{
+		{
+			name:     "Equal",
+			old:      base,
+			new:      &test.Gateway{Upstreams: []*test.Upstream{{Name: "a", Addr: "10.0.0.1"}}, Tags: []string{"base"}, Routes: map[string]*test.Upstream{"/": {Name: "a"}}},
+			expected: nil,
+		},
+		{
+			name:     "FromNil",
+			old:      nil,
+			new:      base,
+			expected: []string{"upstreams", "tags", "routes"},
+		},
+		{
+			name:     "ToEmpty",
+			old:      base,
+			new:      &test.Gateway{},
+			expected: []string{"upstreams", "tags", "routes"},
+		},
+		{
+			name:     "ListElement",
+			old:      base,
+			new:      &test.Gateway{Upstreams: []*test.Upstream{{Name: "a", Addr: "10.0.0.2"}}, Tags: []string{"base"}, Routes: map[string]*test.Upstream{"/": {Name: "a"}}},
+			expected: []string{"upstreams"},
+		},
+		{
+			name:     "MapValue",
+			old:      base,
+			new:      &test.Gateway{Upstreams: []*test.Upstream{{Name: "a", Addr: "10.0.0.1"}}, Tags: []string{"base"}, Routes: map[string]*test.Upstream{"/": {Name: "b"}}},
+			expected: []string{"routes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.old, tt.new).GetPaths()
+			if !reflect.DeepEqual(tt.expected, got) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEvent(t *testing.T) {
+	v1 := &test.Config{Field1: "a"}
+	v2 := &test.Config{Field1: "b"}
+	v3 := &test.Config{Field1: "a", Field2: "c"}
+
+	e1 := Event[*test.Config]{Old: v1, New: v2, Changed: Diff(v1, v2)}
+	e2 := Event[*test.Config]{Old: v2, New: v3, Changed: Diff(v2, v3)}
+
+	if !e1.HasChanged("field1") || e1.HasChanged("field2") {
+		t.Errorf("Expected only field1 to change, got %v", e1.Changed.GetPaths())
+	}
+
+	merged := e1.Merge(e2)
+	if merged.Old != v1 || merged.New != v3 {
+		t.Errorf("Expected merged event from v1 to v3, got %v to %v", merged.Old, merged.New)
+	}
+	if !reflect.DeepEqual([]string{"field2"}, merged.Changed.GetPaths()) {
+		t.Errorf("Expected [field2], got %v", merged.Changed.GetPaths())
+	}
+}
+
+func TestEventHasChangedNested(t *testing.T) {
+	e := Event[*test.Config]{}
+	e.Changed = Diff(&test.Config{}, &test.Config{})
+	e.Changed.Paths = []string{"redis.addr", "grpc"}
+
+	tests := map[string]bool{
+		"redis":      true,
+		"redis.addr": true,
+		"redis.db":   false,
+		"grpc.port":  true,
+		"red":        false,
+	}
+	for path, expected := range tests {
+		if got := e.HasChanged(path); got != expected {
+			t.Errorf("HasChanged(%q): expected %v, got %v", path, expected, got)
+		}
+	}
+}
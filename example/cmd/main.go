@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/go-leo/config/example/configs"
+	"github.com/go-leo/config/resource"
 	"github.com/go-leo/config/resource/env"
 	"github.com/go-leo/config/resource/file"
 )
@@ -49,16 +50,18 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	resources := []resource.Resource{envRsc, jsonRsc, yamlRsc}
 	// 加载配置
-	if err := configs.LoadApplicationConfig(context.TODO(), envRsc, jsonRsc, yamlRsc); err != nil {
+	if err := configs.LoadApplicationConfig(context.TODO(), resources); err != nil {
 		panic(err)
 	}
-	// 获取配置
-	fmt.Println(configs.GetApplicationConfig())
+	// 获取配置，Redacted隐藏敏感字段
+	fmt.Println(configs.GetApplicationConfig().Redacted())
 	// 监听配置
-	// sigC 当有配置更新时，会发送通知。
+	// sigC 当有配置更新时，会发送通知，通知中包含新旧配置和变化的字段。
+	// errC 接收重新加载失败的错误，例如校验失败，之前的配置保持生效。
 	// stop 用于停止监听。
-	sigC, stop, err := configs.WatchApplicationConfig(context.TODO(), envRsc, jsonRsc, yamlRsc)
+	sigC, errC, stop, err := configs.WatchApplicationConfig(context.TODO(), resources)
 	if err != nil {
 		panic(err)
 	}
@@ -68,18 +71,24 @@ func main() {
 	}()
 
 	go func() {
-		for range sigC {
-			fmt.Println(configs.GetApplicationConfig())
+		for event := range sigC {
+			fmt.Println(event)
+		}
+	}()
+
+	go func() {
+		for err := range errC {
+			fmt.Println("reload failed:", err)
 		}
 	}()
 
 	go func() {
 		for {
 			time.Sleep(time.Second)
-			if err := os.WriteFile(jsonFilename, genConfigJSON(), 0o644); err != nil {
+			if err := writeFile(jsonFilename, genConfigJSON()); err != nil {
 				panic(err)
 			}
-			if err := os.WriteFile(yamlFilename, genConfigYaml(), 0o644); err != nil {
+			if err := writeFile(yamlFilename, genConfigYaml()); err != nil {
 				panic(err)
 			}
 		}
@@ -88,6 +97,14 @@ func main() {
 	time.Sleep(11*time.Second)
 }
 
+// writeFile replaces the file at once, so that a reload never reads a partially written file.
+func writeFile(filename string, data []byte) error {
+	if err := os.WriteFile(filename+".tmp", data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(filename+".tmp", filename)
+}
+
 func genConfigJSON() []byte {
 	return []byte(fmt.Sprintf(`{"grpc":{"addr":"127.0.0.1","port":%d}}`, time.Now().Unix()))
 }
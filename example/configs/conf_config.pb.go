@@ -7,60 +7,57 @@ import (
 	config "github.com/go-leo/config"
 	resource "github.com/go-leo/config/resource"
 	proto "google.golang.org/protobuf/proto"
-	sync "sync"
 )
 
-var (
-	_ApplicationConfig      = &Application{}
-	_ApplicationConfigMutex sync.RWMutex
-)
+func DefaultApplicationConfig() *Application {
+	conf, err := config.Default[*Application]()
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+var _ApplicationConfigStore = config.NewStore[*Application](&Application{})
+
+func init() {
+	_ApplicationConfigStore.Set(DefaultApplicationConfig())
+}
+
+func ApplicationConfigStore() *config.Store[*Application] {
+	return _ApplicationConfigStore
+}
 
 func GetApplicationConfig() *Application {
-	_ApplicationConfigMutex.RLock()
-	cloned := proto.Clone(_ApplicationConfig)
-	_ApplicationConfigMutex.RUnlock()
-	return cloned.(*Application)
+	return proto.Clone(_ApplicationConfigStore.Get()).(*Application)
 }
 
 func SetApplicationConfig(conf *Application) {
-	cloned := proto.Clone(conf).(*Application)
-	_ApplicationConfigMutex.Lock()
-	_ApplicationConfig = cloned
-	_ApplicationConfigMutex.Unlock()
+	_ApplicationConfigStore.Set(proto.Clone(conf).(*Application))
 }
 
-func LoadApplicationConfig(ctx context.Context, resources ...resource.Resource) error {
-	conf, err := config.Load[*Application](ctx, resources...)
-	if err != nil {
-		return err
-	}
-	SetApplicationConfig(conf)
-	return nil
+func LoadApplicationConfig(ctx context.Context, resources []resource.Resource, opts ...config.Option) error {
+	return _ApplicationConfigStore.Load(ctx, resources, opts...)
 }
 
-func WatchApplicationConfig(ctx context.Context, resources ...resource.Resource) (<-chan struct{}, func(context.Context) error, error) {
-	notifyC := make(chan *Application)
-	errC := make(chan error)
-	stop, err := config.Watch(ctx, notifyC, errC, resources...)
+func WatchApplicationConfig(ctx context.Context, resources []resource.Resource, opts ...config.Option) (<-chan config.Event[*Application], <-chan error, func(context.Context) error, error) {
+	changedC, errC, err := _ApplicationConfigStore.Watch(ctx, resources, opts...)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	changedC := make(chan struct{}, 1)
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case conf := <-notifyC:
-				SetApplicationConfig(conf)
-				select {
-				case <-ctx.Done():
-					return
-				case changedC <- struct{}{}:
-				default:
-				}
-			}
-		}
-	}()
-	return changedC, stop, nil
+	return changedC, errC, _ApplicationConfigStore.Close, nil
+}
+
+// Redacted returns a copy of x with the sensitive fields masked, for logging.
+func (x *Application) Redacted() *Application {
+	return config.Redact(x)
+}
+
+// Redacted returns a copy of x with the sensitive fields masked, for logging.
+func (x *GRPC) Redacted() *GRPC {
+	return config.Redact(x)
+}
+
+// Redacted returns a copy of x with the sensitive fields masked, for logging.
+func (x *Redis) Redacted() *Redis {
+	return config.Redact(x)
 }
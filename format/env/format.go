@@ -3,6 +3,7 @@ package env
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/go-leo/config/format"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -16,26 +17,119 @@ func init() {
 // Env implements the Formatter interface for environment variables format.
 type Env struct{}
 
-// Parse converts environment variables format data into a protobuf Struct.
-// The input data is expected to be a sequence of KEY=VALUE lines separated by newlines.
+// Parse converts dotenv (.env) format data into a protobuf Struct.
+// Every line is a KEY=VALUE pair, optionally prefixed by "export".
+// Blank lines and lines starting with # are ignored. Values may be:
+//   - unquoted: surrounding whitespace is trimmed and a # preceded by whitespace starts a comment
+//   - single-quoted: taken literally, may span multiple lines
+//   - double-quoted: may span multiple lines, \n \r \t \\ \" \' escapes are expanded and other escapes are kept
+//
+// References such as ${NAME} are kept as they are, they are expanded by the config interpolation.
+// When a key is repeated, the last value wins.
 //
 // Args:
 //
-//	data ([]byte) - Raw byte slice containing key-value pairs in KEY=VALUE format
+//	data ([]byte) - Raw byte slice containing the dotenv file
 //
 // Returns:
 // - *structpb.Struct: Parsed structured data with string values
-// - error: Error if parsing fails
+// - error: Error with the line number if parsing fails
 func (Env) Parse(data []byte) (*structpb.Struct, error) {
-	s := bytes.Split(data, []byte("\n"))
-	m := make(map[string]any, len(s))
-
-	for _, v := range s {
-		pair := bytes.SplitN(v, []byte("="), 2)
-		if len(pair) != 2 {
-			return nil, fmt.Errorf("invalid line: %s", v)
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	m := make(map[string]any, len(lines))
+	for i := 0; i < len(lines); i++ {
+		lineno := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if rest := strings.TrimPrefix(line, "export"); len(rest) < len(line) && rest != "" && isSpace(rest[0]) {
+			line = strings.TrimSpace(rest)
+		}
+		key, rest, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("env: line %d: missing '=' in %q", lineno, line)
+		}
+		key = strings.TrimSpace(key)
+		if !validKey(key) {
+			return nil, fmt.Errorf("env: line %d: invalid key %q", lineno, key)
 		}
-		m[string(pair[0])] = string(pair[1])
+		trimmed := strings.TrimLeft(rest, " \t")
+		if trimmed != "" && (trimmed[0] == '\'' || trimmed[0] == '"') {
+			value, next, err := parseQuoted(lines, i, trimmed)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+			i = next
+			continue
+		}
+		m[key] = parseUnquoted(rest)
 	}
 	return structpb.NewStruct(m)
 }
+
+// parseUnquoted returns the value without the inline comment and surrounding whitespace.
+func parseUnquoted(value string) string {
+	for i := 1; i < len(value); i++ {
+		if value[i] == '#' && isSpace(value[i-1]) {
+			value = value[:i]
+			break
+		}
+	}
+	return strings.TrimSpace(value)
+}
+
+// parseQuoted parses the quoted value starting at lines[start], which may span
+// the following lines. value begins with the opening quote.
+// It returns the value and the index of the line holding the closing quote.
+func parseQuoted(lines []string, start int, value string) (string, int, error) {
+	quote := value[0]
+	s := value[1:]
+	var b strings.Builder
+	for i := start; ; {
+		for j := 0; j < len(s); j++ {
+			c := s[j]
+			if c == quote {
+				if rest := strings.TrimSpace(s[j+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+					return "", 0, fmt.Errorf("env: line %d: unexpected %q after closing quote", i+1, rest)
+				}
+				return b.String(), i, nil
+			}
+			if c == '\\' && quote == '"' && j+1 < len(s) {
+				j++
+				switch s[j] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				case '\\', '"', '\'':
+					b.WriteByte(s[j])
+				default:
+					b.WriteByte('\\')
+					b.WriteByte(s[j])
+				}
+				continue
+			}
+			b.WriteByte(c)
+		}
+		i++
+		if i >= len(lines) {
+			return "", 0, fmt.Errorf("env: line %d: unterminated quoted value", start+1)
+		}
+		b.WriteByte('\n')
+		s = lines[i]
+	}
+}
+
+// validKey reports whether key is a non-empty name without whitespace or quotes.
+func validKey(key string) bool {
+	return key != "" && !strings.ContainsAny(key, " \t'\"")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
@@ -1,84 +1,155 @@
 package env
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 )
 
 func TestParse_ValidEnvFormat(t *testing.T) {
 	data := []byte("KEY1=VALUE1\nKEY2=VALUE2")
 	result, err := Env{}.Parse(data)
-
-	// Expect error due to redundant UnmarshalJSON call in current implementation
-	if err == nil {
-		t.Errorf("Expected error from UnmarshalJSON but got nil")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
 	}
-	if result != nil {
-		t.Errorf("Expected nil result but got %v", result)
+	expected := map[string]any{"KEY1": "VALUE1", "KEY2": "VALUE2"}
+	if !reflect.DeepEqual(expected, result.AsMap()) {
+		t.Errorf("Expected %v but got %v", expected, result.AsMap())
 	}
 }
 
-func TestParse_MalformedInput(t *testing.T) {
+func TestParse_Dotenv(t *testing.T) {
 	tests := []struct {
-		name    string
-		input   []byte
-		wantErr bool
+		name  string
+		input string
+		want  map[string]any
 	}{
 		{
-			name:    "MissingValue",
-			input:   []byte("KEY"),
-			wantErr: true,
+			name:  "Empty",
+			input: "",
+			want:  map[string]any{},
+		},
+		{
+			name:  "BlankLinesAndComments",
+			input: "\n# comment\n  # indented comment\nKEY=VALUE\n\n",
+			want:  map[string]any{"KEY": "VALUE"},
+		},
+		{
+			name:  "CRLF",
+			input: "A=1\r\nB=2\r\n",
+			want:  map[string]any{"A": "1", "B": "2"},
+		},
+		{
+			name:  "Export",
+			input: "export KEY=VALUE\nexport\tOTHER=1\nexport=2",
+			want:  map[string]any{"KEY": "VALUE", "OTHER": "1", "export": "2"},
+		},
+		{
+			name:  "Whitespace",
+			input: "  KEY  =  VALUE  ",
+			want:  map[string]any{"KEY": "VALUE"},
+		},
+		{
+			name:  "EmptyValue",
+			input: "A=\nB=''\nC=\"\"",
+			want:  map[string]any{"A": "", "B": "", "C": ""},
+		},
+		{
+			name:  "MultipleEquals",
+			input: "KEY=VALUE=MORE",
+			want:  map[string]any{"KEY": "VALUE=MORE"},
+		},
+		{
+			name:  "SpecialCharacters",
+			input: "PATH=/usr/bin:$HOME\nREF=${HOST:localhost}",
+			want:  map[string]any{"PATH": "/usr/bin:$HOME", "REF": "${HOST:localhost}"},
+		},
+		{
+			name:  "InlineComment",
+			input: "A=1 # comment\nB=a#b\nC=#c\nD= # comment",
+			want:  map[string]any{"A": "1", "B": "a#b", "C": "#c", "D": ""},
+		},
+		{
+			name:  "SingleQuoted",
+			input: `KEY='  a \n "b" # c  ' # comment`,
+			want:  map[string]any{"KEY": `  a \n "b" # c  `},
+		},
+		{
+			name:  "DoubleQuoted",
+			input: `KEY="a\nb\tc \"d\" \\ \'e\' \x # f" # comment`,
+			want:  map[string]any{"KEY": "a\nb\tc \"d\" \\ 'e' \\x # f"},
 		},
 		{
-			name:    "MultipleEquals",
-			input:   []byte("KEY=VALUE=MORE"),
-			wantErr: true,
+			name:  "MultiLine",
+			input: "CERT=\"-----BEGIN-----\nabc\n-----END-----\"\nKEY='a\n\nb'\nNEXT=1",
+			want:  map[string]any{"CERT": "-----BEGIN-----\nabc\n-----END-----", "KEY": "a\n\nb", "NEXT": "1"},
 		},
 		{
-			name:    "EmptyLine",
-			input:   []byte("\nKEY=VALUE\n"),
-			wantErr: true,
+			name:  "Duplicate",
+			input: "KEY=1\nKEY=2",
+			want:  map[string]any{"KEY": "2"},
+		},
+		{
+			name:  "BOM",
+			input: "\xef\xbb\xbfKEY=VALUE",
+			want:  map[string]any{"KEY": "VALUE"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := Env{}.Parse(tt.input)
-			if tt.wantErr && err == nil {
-				t.Errorf("Expected error but got nil")
-			}
-			if !tt.wantErr && err != nil {
-				t.Errorf("Unexpected error: %v", err)
+			result, err := Env{}.Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
 			}
-			if result != nil {
-				t.Errorf("Expected nil result but got %v", result)
+			if !reflect.DeepEqual(tt.want, result.AsMap()) {
+				t.Errorf("Expected %q but got %q", tt.want, result.AsMap())
 			}
 		})
 	}
 }
 
-func TestParse_CornerCases(t *testing.T) {
+func TestParse_MalformedInput(t *testing.T) {
 	tests := []struct {
 		name    string
-		input   []byte
-		wantErr bool
+		input   string
+		wantErr string
 	}{
 		{
-			name:    "Whitespace",
-			input:   []byte("  KEY  =  VALUE  "),
-			wantErr: true,
+			name:    "MissingValue",
+			input:   "A=1\nKEY",
+			wantErr: `env: line 2: missing '=' in "KEY"`,
 		},
 		{
-			name:    "SpecialCharacters",
-			input:   []byte("PATH=/usr/bin:$HOME"),
-			wantErr: true,
+			name:    "EmptyKey",
+			input:   "=VALUE",
+			wantErr: `env: line 1: invalid key ""`,
+		},
+		{
+			name:    "InvalidKey",
+			input:   "\nMY KEY=VALUE",
+			wantErr: `env: line 2: invalid key "MY KEY"`,
+		},
+		{
+			name:    "UnterminatedQuote",
+			input:   "A=1\nKEY=\"abc\nB=2",
+			wantErr: "env: line 2: unterminated quoted value",
+		},
+		{
+			name:    "TrailingCharacters",
+			input:   "A='x'\nB='a\nb' c",
+			wantErr: `env: line 3: unexpected "c" after closing quote`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := Env{}.Parse(tt.input)
-			if tt.wantErr && err == nil {
-				t.Errorf("Expected error but got nil")
+			result, err := Env{}.Parse([]byte(tt.input))
+			if err == nil {
+				t.Fatalf("Expected error but got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error %q but got %q", tt.wantErr, err)
 			}
 			if result != nil {
 				t.Errorf("Expected nil result but got %v", result)
@@ -28,6 +28,14 @@ type Formatter interface {
 	Parse(data []byte) (*structpb.Struct, error)
 }
 
+// Blocks is an optional interface implemented by formatters that parse a
+// single block into an object and repeated blocks into a list, such as HCL,
+// so that an object may stand for a list of one object.
+type Blocks interface {
+	// Blocks reports whether a single object stands for a list of one object.
+	Blocks() bool
+}
+
 // RegisterFormatter associates a file extension with a configuration parser
 //
 // Args:
@@ -0,0 +1,163 @@
+package hcl
+
+import (
+	"fmt"
+
+	"github.com/go-leo/config/format"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// init registers the Hcl formatter with the global format registry.
+func init() {
+	format.RegisterFormatter("hcl", Hcl{})
+}
+
+// Hcl implements the Formatter interface for HCL format.
+type Hcl struct{}
+
+// Parse converts HCL-formatted byte data into a Protocol Buffer Struct object.
+//
+// Attributes are evaluated without variables or functions, so ${...} templates
+// have to be escaped as $${...} to be left for the config interpolation.
+// Blocks are mapped as follows:
+//   - a block without labels is an object under its type, several of them are a list
+//   - the labels of a block are nested keys under its type,
+//     e.g. upstream "api" { ... } sets {"upstream": {"api": {...}}}
+//   - blocks of the same type and labels are a list
+//
+// As a single block is not a list, Hcl implements format.Blocks: an object
+// fills a repeated message field as a list of one item.
+//
+// Args:
+//
+//	data ([]byte): The HCL-formatted byte slice to be parsed
+//
+// Returns:
+//
+//	*structpb.Struct: A protobuf Struct object representing the parsed data
+//	error: An error if parsing fails (e.g., invalid HCL syntax or an expression that cannot be evaluated)
+func (Hcl) Parse(data []byte) (*structpb.Struct, error) {
+	file, diags := hclsyntax.ParseConfig(data, "hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	v, diags := bodyValue(file.Body.(*hclsyntax.Body))
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return structpb.NewStruct(v)
+}
+
+// Blocks reports that a single HCL block stands for a list of one block.
+func (Hcl) Blocks() bool {
+	return true
+}
+
+// bodyValue converts the attributes and blocks of body into a map.
+func bodyValue(body *hclsyntax.Body) (map[string]any, hcl.Diagnostics) {
+	m := make(map[string]any, len(body.Attributes)+len(body.Blocks))
+	for name, attr := range body.Attributes {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		v, err := ctyValue(value)
+		if err != nil {
+			return nil, hcl.Diagnostics{{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported value",
+				Detail:   err.Error(),
+				Subject:  attr.Expr.Range().Ptr(),
+			}}
+		}
+		m[name] = v
+	}
+	labels := make(map[string]int, len(body.Blocks))
+	for _, block := range body.Blocks {
+		if _, ok := body.Attributes[block.Type]; ok {
+			return nil, blockError(block, "Duplicate name", "An attribute named "+block.Type+" is already defined.")
+		}
+		if n, ok := labels[block.Type]; ok && n != len(block.Labels) {
+			return nil, blockError(block, "Inconsistent labels", "All "+block.Type+" blocks must have the same number of labels.")
+		}
+		labels[block.Type] = len(block.Labels)
+		v, diags := bodyValue(block.Body)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		parent, key := m, block.Type
+		for _, label := range block.Labels {
+			child, ok := parent[key].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				parent[key] = child
+			}
+			parent, key = child, label
+		}
+		switch existing := parent[key].(type) {
+		case nil:
+			parent[key] = v
+		case []any:
+			parent[key] = append(existing, v)
+		default:
+			parent[key] = []any{existing, v}
+		}
+	}
+	return m, nil
+}
+
+// ctyValue converts value into the Go representation accepted by structpb.
+func ctyValue(value cty.Value) (any, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	if !value.IsWhollyKnown() {
+		return nil, fmt.Errorf("value is not known")
+	}
+	ty := value.Type()
+	switch {
+	case ty == cty.String:
+		return value.AsString(), nil
+	case ty == cty.Number:
+		f, _ := value.AsBigFloat().Float64()
+		return f, nil
+	case ty == cty.Bool:
+		return value.True(), nil
+	case ty.IsListType() || ty.IsTupleType() || ty.IsSetType():
+		list := make([]any, 0, value.LengthInt())
+		for it := value.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			v, err := ctyValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case ty.IsMapType() || ty.IsObjectType():
+		m := make(map[string]any, value.LengthInt())
+		for it := value.ElementIterator(); it.Next(); {
+			key, elem := it.Element()
+			v, err := ctyValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			m[key.AsString()] = v
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", ty.FriendlyName())
+	}
+}
+
+func blockError(block *hclsyntax.Block, summary string, detail string) hcl.Diagnostics {
+	return hcl.Diagnostics{{
+		Severity: hcl.DiagError,
+		Summary:  summary,
+		Detail:   detail,
+		Subject:  block.TypeRange.Ptr(),
+	}}
+}
@@ -0,0 +1,165 @@
+package hcl
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParse_Success tests successful parsing of valid HCL data.
+func TestParse_Success(t *testing.T) {
+	data := []byte("name = \"Alice\"\nage = 30")
+	parser := Hcl{}
+	result, err := parser.Parse(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result == nil {
+		t.Fatalf("Expected non-nil result, got nil")
+	}
+
+	expectedMap := map[string]interface{}{
+		"name": "Alice",
+		"age":  float64(30),
+	}
+
+	if !reflect.DeepEqual(expectedMap, result.AsMap()) {
+		t.Errorf("Expected map %v, got %v", expectedMap, result.AsMap())
+	}
+}
+
+// TestParse_Blocks tests the mapping of attributes and blocks.
+func TestParse_Blocks(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]interface{}
+	}{
+		{
+			name: "Values",
+			input: `
+debug   = true
+ratio   = 0.5
+tags    = ["a", "b"]
+limits  = { max_conns = 10, "rate" = 1 + 1 }
+nothing = null
+ref     = "$${HOST}:${"6379"}"
+heredoc = <<EOT
+hello
+EOT
+`,
+			want: map[string]interface{}{
+				"debug":   true,
+				"ratio":   0.5,
+				"tags":    []interface{}{"a", "b"},
+				"limits":  map[string]interface{}{"max_conns": float64(10), "rate": float64(2)},
+				"nothing": nil,
+				"ref":     "${HOST}:6379",
+				"heredoc": "hello\n",
+			},
+		},
+		{
+			name: "Block",
+			input: `
+redis {
+  addr = "127.0.0.1:6379"
+  tls {
+    enabled = true
+  }
+}
+`,
+			want: map[string]interface{}{
+				"redis": map[string]interface{}{
+					"addr": "127.0.0.1:6379",
+					"tls":  map[string]interface{}{"enabled": true},
+				},
+			},
+		},
+		{
+			name: "RepeatedBlocks",
+			input: `
+upstream {
+  name = "a"
+}
+upstream {
+  name = "b"
+}
+`,
+			want: map[string]interface{}{
+				"upstream": []interface{}{
+					map[string]interface{}{"name": "a"},
+					map[string]interface{}{"name": "b"},
+				},
+			},
+		},
+		{
+			name: "LabelledBlocks",
+			input: `
+route "api" "v1" {
+  addr = "10.0.0.1"
+}
+route "api" "v2" {
+  addr = "10.0.0.2"
+}
+route "web" "v1" {
+  addr = "10.0.0.3"
+}
+route "web" "v1" {
+  addr = "10.0.0.4"
+}
+`,
+			want: map[string]interface{}{
+				"route": map[string]interface{}{
+					"api": map[string]interface{}{
+						"v1": map[string]interface{}{"addr": "10.0.0.1"},
+						"v2": map[string]interface{}{"addr": "10.0.0.2"},
+					},
+					"web": map[string]interface{}{
+						"v1": []interface{}{
+							map[string]interface{}{"addr": "10.0.0.3"},
+							map[string]interface{}{"addr": "10.0.0.4"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Hcl{}.Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(tt.want, result.AsMap()) {
+				t.Errorf("Expected map %v, got %v", tt.want, result.AsMap())
+			}
+		})
+	}
+}
+
+// TestParse_InvalidHCL tests error handling for invalid HCL format.
+func TestParse_InvalidHCL(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "Syntax", input: "name = \"Alice\"\nage = 30, 40"},
+		{name: "Variable", input: "addr = \"${HOST}\""},
+		{name: "Function", input: "addr = upper(\"a\")"},
+		{name: "AttributeAndBlock", input: "redis = 1\nredis {\n}"},
+		{name: "InconsistentLabels", input: "route \"a\" {\n}\nroute {\n}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Hcl{}.Parse([]byte(tt.input))
+			if err == nil {
+				t.Fatalf("Expected error, got nil")
+			}
+			if result != nil {
+				t.Errorf("Expected nil result, got %v", result)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package ini
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-leo/config/format"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// init registers the Ini formatter with the global format registry.
+func init() {
+	format.RegisterFormatter("ini", Ini{})
+}
+
+// Ini implements the Formatter interface for INI format.
+type Ini struct{}
+
+// Parse converts INI data into a protobuf Struct.
+//   - lines starting with ; or # are comments, a ; or # preceded by whitespace starts an inline comment
+//   - keys and values are separated by = or :
+//   - a line ending with a backslash continues on the next line
+//   - double-quoted values may contain Go escapes such as \n, \" and \u00e9, single-quoted values are taken literally
+//
+// Keys before the first section are top level. Section names and keys are split on dots
+// into nested structs, e.g. redis.addr in [app] sets {"app": {"redis": {"addr": ...}}}.
+// Repeated sections are merged. All values are strings. When a key is repeated, the last value wins.
+// The last key or section also wins when a name is both a value and the parent of nested keys,
+// e.g. level=INFO followed by level.root=DEBUG sets {"level": {"root": "DEBUG"}}, the other order sets "INFO".
+//
+// Args:
+//
+//	data ([]byte) - Raw byte slice containing the INI file
+//
+// Returns:
+// - *structpb.Struct: Parsed structured data with string values
+// - error: Error with the line number if parsing fails
+func (Ini) Parse(data []byte) (*structpb.Struct, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	value := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	section := value
+	for i := 0; i < len(lines); i++ {
+		lineno := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			name, rest, ok := strings.Cut(line[1:], "]")
+			if !ok {
+				return nil, fmt.Errorf("ini: line %d: missing ']' in %q", lineno, line)
+			}
+			if rest = strings.TrimSpace(rest); rest != "" && rest[0] != ';' && rest[0] != '#' {
+				return nil, fmt.Errorf("ini: line %d: unexpected %q after section", lineno, rest)
+			}
+			name = strings.TrimSpace(name)
+			s, err := nestedStruct(value, strings.Split(name, "."))
+			if err != nil {
+				return nil, fmt.Errorf("ini: line %d: section %q %w", lineno, name, err)
+			}
+			section = s
+			continue
+		}
+		// join continuation lines
+		for strings.HasSuffix(line, `\`) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimSpace(lines[i])
+		}
+		line = strings.TrimSuffix(line, `\`)
+		end := strings.IndexAny(line, "=:")
+		if end < 0 {
+			return nil, fmt.Errorf("ini: line %d: missing '=' or ':' in %q", lineno, line)
+		}
+		key := strings.TrimSpace(line[:end])
+		val, err := parseValue(strings.TrimSpace(line[end+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("ini: line %d: %w", lineno, err)
+		}
+		keys := strings.Split(key, ".")
+		parent, err := nestedStruct(section, keys[:len(keys)-1])
+		if err == nil {
+			err = setValue(parent, keys[len(keys)-1], structpb.NewStringValue(val))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ini: line %d: key %q %w", lineno, key, err)
+		}
+	}
+	return value, nil
+}
+
+// parseValue removes the quotes or the inline comment of value.
+func parseValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	quote := value[0]
+	if quote != '"' && quote != '\'' {
+		for i := 1; i < len(value); i++ {
+			if (value[i] == ';' || value[i] == '#') && (value[i-1] == ' ' || value[i-1] == '\t') {
+				return strings.TrimSpace(value[:i]), nil
+			}
+		}
+		return value, nil
+	}
+	end := -1
+	for i := 1; i < len(value); i++ {
+		if value[i] == '\\' && quote == '"' {
+			i++
+			continue
+		}
+		if value[i] == quote {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return "", fmt.Errorf("unterminated quoted value %s", value)
+	}
+	if rest := strings.TrimSpace(value[end+1:]); rest != "" && rest[0] != ';' && rest[0] != '#' {
+		return "", fmt.Errorf("unexpected %q after closing quote", rest)
+	}
+	if quote == '\'' {
+		return value[1:end], nil
+	}
+	unquoted, err := strconv.Unquote(value[:end+1])
+	if err != nil {
+		return "", fmt.Errorf("invalid escape in %s", value[:end+1])
+	}
+	return unquoted, nil
+}
+
+// nestedStruct returns the struct at the path of keys, creating nested structs.
+// A value on the path is replaced by a nested struct.
+func nestedStruct(s *structpb.Struct, keys []string) (*structpb.Struct, error) {
+	for _, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("has an empty key")
+		}
+		nested := s.GetFields()[key]
+		if nested.GetStructValue() == nil {
+			nested = structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{}})
+			s.Fields[key] = nested
+		}
+		s = nested.GetStructValue()
+	}
+	return s, nil
+}
+
+// setValue sets the value of key, replacing an existing value and its nested keys.
+func setValue(s *structpb.Struct, key string, value *structpb.Value) error {
+	if key == "" {
+		return fmt.Errorf("has an empty key")
+	}
+	s.Fields[key] = value
+	return nil
+}
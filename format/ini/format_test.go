@@ -0,0 +1,143 @@
+package ini
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParse_Success tests successful parsing of valid INI data.
+func TestParse_Success(t *testing.T) {
+	data := []byte("name = Alice\n\n[redis]\naddr = 127.0.0.1:6379\ndb: 1\n\n[grpc.tls]\nenabled = true")
+	parser := Ini{}
+	result, err := parser.Parse(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedMap := map[string]interface{}{
+		"name":  "Alice",
+		"redis": map[string]interface{}{"addr": "127.0.0.1:6379", "db": "1"},
+		"grpc":  map[string]interface{}{"tls": map[string]interface{}{"enabled": "true"}},
+	}
+
+	if !reflect.DeepEqual(expectedMap, result.AsMap()) {
+		t.Errorf("Expected map %v, got %v", expectedMap, result.AsMap())
+	}
+}
+
+func TestParse_Syntax(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]any
+	}{
+		{
+			name:  "Comments",
+			input: "; comment\n# comment\n[a] ; comment\nb = 1 ; comment\nc = 2 # comment\nd = x;y#z",
+			want:  map[string]any{"a": map[string]any{"b": "1", "c": "2", "d": "x;y#z"}},
+		},
+		{
+			name:  "EmptySection",
+			input: "[a]\n[b]\nc=",
+			want:  map[string]any{"a": map[string]any{}, "b": map[string]any{"c": ""}},
+		},
+		{
+			name:  "RepeatedSection",
+			input: "[a]\nb=1\nc=1\n[d]\n[a]\nc=2",
+			want:  map[string]any{"a": map[string]any{"b": "1", "c": "2"}, "d": map[string]any{}},
+		},
+		{
+			name:  "DottedKeys",
+			input: "[app]\nredis.addr = 127.0.0.1\nredis.db = 1",
+			want:  map[string]any{"app": map[string]any{"redis": map[string]any{"addr": "127.0.0.1", "db": "1"}}},
+		},
+		{
+			name:  "Continuation",
+			input: "hosts = a, \\\n  b, \\\n  c\nnext = 1",
+			want:  map[string]any{"hosts": "a, b, c", "next": "1"},
+		},
+		{
+			name:  "Quoted",
+			input: "a = \"x ; \\\"y\\\"\\n\\u00e9\" ; comment\nb = ' # z\\n '",
+			want:  map[string]any{"a": "x ; \"y\"\né", "b": ` # z\n `},
+		},
+		{
+			name:  "LastWinsNestedOverValue",
+			input: "level=INFO\nlevel.root=DEBUG\nname=a\n[name]\nfirst=b",
+			want:  map[string]any{"level": map[string]any{"root": "DEBUG"}, "name": map[string]any{"first": "b"}},
+		},
+		{
+			name:  "LastWinsValueOverNested",
+			input: "[app]\nlevel.root=DEBUG\nlevel=INFO\n[app.redis]\naddr=x\n[app]\nredis=y",
+			want:  map[string]any{"app": map[string]any{"level": "INFO", "redis": "y"}},
+		},
+		{
+			name:  "CRLF",
+			input: "[a]\r\nb=1\r\n",
+			want:  map[string]any{"a": map[string]any{"b": "1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Ini{}.Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.want, result.AsMap()) {
+				t.Errorf("Expected %q, got %q", tt.want, result.AsMap())
+			}
+		})
+	}
+}
+
+// TestParse_Invalid tests error handling for invalid INI data.
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "MissingSeparator",
+			input:   "[a]\nkey",
+			wantErr: `ini: line 2: missing '=' or ':' in "key"`,
+		},
+		{
+			name:    "UnclosedSection",
+			input:   "[a",
+			wantErr: `ini: line 1: missing ']' in "[a"`,
+		},
+		{
+			name:    "EmptySection",
+			input:   "a=1\n[]",
+			wantErr: `ini: line 2: section "" has an empty key`,
+		},
+		{
+			name:    "UnterminatedQuote",
+			input:   "a=\"b",
+			wantErr: `ini: line 1: unterminated quoted value "b`,
+		},
+		{
+			name:    "InvalidEscape",
+			input:   `a="\q"`,
+			wantErr: `ini: line 1: invalid escape in "\q"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Ini{}.Parse([]byte(tt.input))
+			if err == nil {
+				t.Fatalf("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error %q, got %q", tt.wantErr, err)
+			}
+			if result != nil {
+				t.Errorf("Expected nil result, got %v", result)
+			}
+		})
+	}
+}
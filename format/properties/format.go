@@ -0,0 +1,186 @@
+package properties
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/go-leo/config/format"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// init registers the Properties formatter with the global format registry.
+func init() {
+	format.RegisterFormatter("properties", Properties{})
+}
+
+// Properties implements the Formatter interface for Java properties format.
+type Properties struct{}
+
+// Parse converts Java properties data into a protobuf Struct.
+// The format follows java.util.Properties:
+//   - lines starting with # or ! are comments
+//   - the key ends at the first unescaped =, : or whitespace
+//   - a line ending with an odd number of backslashes continues on the next line
+//   - \t \n \r \f and \uXXXX escapes are expanded, other escaped characters stand for themselves
+//
+// Keys are split on dots into nested structs, e.g. redis.addr sets {"redis": {"addr": ...}}.
+// All values are strings. When a key is repeated, the last value wins. The last
+// key also wins when a key is both a value and the parent of nested keys, e.g.
+// logging.level=INFO followed by logging.level.root=DEBUG sets
+// {"logging": {"level": {"root": "DEBUG"}}}, the other order sets "INFO".
+//
+// Args:
+//
+//	data ([]byte) - Raw byte slice containing the properties file
+//
+// Returns:
+// - *structpb.Struct: Parsed structured data with string values
+// - error: Error with the line number if parsing fails
+func (Properties) Parse(data []byte) (*structpb.Struct, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	value := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for i := 0; i < len(lines); i++ {
+		lineno := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		// join continuation lines
+		for continues(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		if continues(line) {
+			line = line[:len(line)-1]
+		}
+		rawKey, rawValue := splitKeyValue(line)
+		key, err := unescape(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("properties: line %d: %w", lineno, err)
+		}
+		val, err := unescape(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("properties: line %d: %w", lineno, err)
+		}
+		if err := setNested(value, strings.Split(key, "."), structpb.NewStringValue(val)); err != nil {
+			return nil, fmt.Errorf("properties: line %d: key %q %w", lineno, key, err)
+		}
+	}
+	return value, nil
+}
+
+// continues reports whether line ends with an odd number of backslashes.
+func continues(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitKeyValue splits a logical line into its still escaped key and value.
+func splitKeyValue(line string) (string, string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+		if c == '=' || c == ':' || isSpace(c) {
+			end = i
+			break
+		}
+	}
+	key, rest := line[:end], strings.TrimLeft(line[end:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	return key, rest
+}
+
+// unescape expands the escape sequences of s.
+func unescape(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 >= len(s) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			r, err := parseUnicode(s[i+1:])
+			if err != nil {
+				return "", err
+			}
+			i += 4
+			// a character outside the BMP is written as a surrogate pair
+			if utf16.IsSurrogate(r) && strings.HasPrefix(s[i+1:], `\u`) {
+				if r2, err := parseUnicode(s[i+3:]); err == nil {
+					if d := utf16.DecodeRune(r, r2); d != unicode.ReplacementChar {
+						r = d
+						i += 6
+					}
+				}
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// parseUnicode parses the 4 hex digits at the beginning of s.
+func parseUnicode(s string) (rune, error) {
+	if len(s) < 4 {
+		return 0, fmt.Errorf(`malformed \uxxxx escape`)
+	}
+	n, err := strconv.ParseUint(s[:4], 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf(`malformed \u%s escape`, s[:4])
+	}
+	return rune(n), nil
+}
+
+// setNested sets the value at the path of keys, creating nested structs.
+// An existing value at the path is replaced, nested keys included, and
+// a value on the path is replaced by a nested struct.
+func setNested(s *structpb.Struct, keys []string, value *structpb.Value) error {
+	for i, key := range keys {
+		if key == "" {
+			return fmt.Errorf("has an empty key")
+		}
+		if i == len(keys)-1 {
+			s.Fields[key] = value
+			return nil
+		}
+		nested := s.GetFields()[key]
+		if nested.GetStructValue() == nil {
+			nested = structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{}})
+			s.Fields[key] = nested
+		}
+		s = nested.GetStructValue()
+	}
+	return nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\f'
+}
@@ -0,0 +1,133 @@
+package properties
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParse_Success tests successful parsing of valid properties data.
+func TestParse_Success(t *testing.T) {
+	data := []byte("name=Alice\nage: 30\nredis.addr = 127.0.0.1:6379\nredis.db 1")
+	parser := Properties{}
+	result, err := parser.Parse(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedMap := map[string]interface{}{
+		"name":  "Alice",
+		"age":   "30",
+		"redis": map[string]interface{}{"addr": "127.0.0.1:6379", "db": "1"},
+	}
+
+	if !reflect.DeepEqual(expectedMap, result.AsMap()) {
+		t.Errorf("Expected map %v, got %v", expectedMap, result.AsMap())
+	}
+}
+
+func TestParse_Syntax(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]any
+	}{
+		{
+			name:  "Comments",
+			input: "# comment\n  ! comment\n\nkey=value # not a comment",
+			want:  map[string]any{"key": "value # not a comment"},
+		},
+		{
+			name:  "Separators",
+			input: "a=1\nb:2\nc 3\nd  =  4\ne\nf=",
+			want:  map[string]any{"a": "1", "b": "2", "c": "3", "d": "4", "e": "", "f": ""},
+		},
+		{
+			name:  "Continuation",
+			input: "fruits = apple, \\\n         banana, \\\n         cherry\nnext=1",
+			want:  map[string]any{"fruits": "apple, banana, cherry", "next": "1"},
+		},
+		{
+			name:  "EscapedBackslash",
+			input: "path=C:\\\\dir\\\\\nnext=1",
+			want:  map[string]any{"path": `C:\dir\`, "next": "1"},
+		},
+		{
+			name:  "Escapes",
+			input: `key\ with\ spaces\=x=a\tb\nc\:d\#e`,
+			want:  map[string]any{"key with spaces=x": "a\tb\nc:d#e"},
+		},
+		{
+			name:  "Unicode",
+			input: "greeting=\\u4f60\\u597d\nemoji=\\ud83d\\ude00",
+			want:  map[string]any{"greeting": "你好", "emoji": "😀"},
+		},
+		{
+			name:  "Duplicate",
+			input: "a.b=1\na.b=2",
+			want:  map[string]any{"a": map[string]any{"b": "2"}},
+		},
+		{
+			name:  "LastWinsNestedOverValue",
+			input: "logging.level=INFO\nlogging.level.root=DEBUG",
+			want:  map[string]any{"logging": map[string]any{"level": map[string]any{"root": "DEBUG"}}},
+		},
+		{
+			name:  "LastWinsValueOverNested",
+			input: "logging.level.root=DEBUG\nlogging.level.web=WARN\nlogging.level=INFO",
+			want:  map[string]any{"logging": map[string]any{"level": "INFO"}},
+		},
+		{
+			name:  "CRLF",
+			input: "a=1\r\nb=2\r\n",
+			want:  map[string]any{"a": "1", "b": "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Properties{}.Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.want, result.AsMap()) {
+				t.Errorf("Expected %q, got %q", tt.want, result.AsMap())
+			}
+		})
+	}
+}
+
+// TestParse_Invalid tests error handling for invalid properties data.
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "MalformedUnicode",
+			input:   "a=1\nb=\\u12g4",
+			wantErr: `properties: line 2: malformed \u12g4 escape`,
+		},
+		{
+			name:    "EmptyKey",
+			input:   "a..b=1",
+			wantErr: `properties: line 1: key "a..b" has an empty key`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Properties{}.Parse([]byte(tt.input))
+			if err == nil {
+				t.Fatalf("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error %q, got %q", tt.wantErr, err)
+			}
+			if result != nil {
+				t.Errorf("Expected nil result, got %v", result)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package xml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-leo/config/format"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// init registers the Xml formatter with the global format registry.
+func init() {
+	format.RegisterFormatter("xml", Xml{})
+}
+
+// TextKey is the key of the text of an element that also has attributes or child elements.
+const TextKey = "#text"
+
+// Xml implements the Formatter interface for XML format.
+type Xml struct{}
+
+// Parse converts XML-formatted byte data into a Protocol Buffer Struct object.
+//
+// The root element is the returned struct, its name is dropped. Below it:
+//   - a child element is a key named after the element without namespace
+//   - repeated child elements of the same name are a list
+//   - an attribute is a key named after the attribute without namespace, like a child
+//     element, an attribute and a child element of the same name are an error.
+//     Namespace declarations are dropped
+//   - the text of an element without attributes and child elements is its value,
+//     otherwise it is kept under TextKey. Surrounding whitespace is trimmed
+//
+// All values are strings, e.g.
+//
+//	<config><redis db="1"><addr>127.0.0.1:6379</addr></redis><tag>a</tag><tag>b</tag></config>
+//
+// is {"redis": {"db": "1", "addr": "127.0.0.1:6379"}, "tag": ["a", "b"]}.
+// Comments, processing instructions and directives are ignored.
+//
+// As a single element is not a list, Xml implements format.Blocks: an object
+// or a value fills a repeated field as a list of one item.
+//
+// Args:
+//
+//	data ([]byte): The XML-formatted byte slice to be parsed
+//
+// Returns:
+//
+//	*structpb.Struct: A protobuf Struct object representing the parsed data
+//	error: An error if parsing fails (e.g., invalid XML syntax or no root element)
+func (Xml) Parse(data []byte) (*structpb.Struct, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root map[string]any
+	var stack []*element
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if root != nil {
+				line, _ := decoder.InputPos()
+				return nil, fmt.Errorf("xml: multiple root elements on line %d", line)
+			}
+			if len(stack) > 0 && stack[len(stack)-1].attrs[token.Name.Local] {
+				line, _ := decoder.InputPos()
+				return nil, fmt.Errorf("xml: element <%s> on line %d has the name of an attribute of its parent", token.Name.Local, line)
+			}
+			e := &element{fields: make(map[string]any), attrs: make(map[string]bool)}
+			for _, attr := range token.Attr {
+				if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					continue
+				}
+				e.fields[attr.Name.Local] = attr.Value
+				e.attrs[attr.Name.Local] = true
+			}
+			stack = append(stack, e)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(token)
+			}
+		case xml.EndElement:
+			e := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				root = e.fields
+				if text := strings.TrimSpace(e.text.String()); text != "" {
+					root[TextKey] = text
+				}
+				continue
+			}
+			stack[len(stack)-1].add(token.Name.Local, e.value())
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("xml: no root element")
+	}
+	return structpb.NewStruct(root)
+}
+
+// Blocks reports that a single XML element stands for a list of one element.
+func (Xml) Blocks() bool {
+	return true
+}
+
+// element is an element being parsed.
+type element struct {
+	fields map[string]any
+	// attrs the names of the attributes in fields
+	attrs map[string]bool
+	text  strings.Builder
+}
+
+// value returns the value of the element once parsed.
+func (e *element) value() any {
+	text := strings.TrimSpace(e.text.String())
+	if len(e.fields) == 0 {
+		return text
+	}
+	if text != "" {
+		e.fields[TextKey] = text
+	}
+	return e.fields
+}
+
+// add adds the value of the child element name, repeated elements make a list.
+func (e *element) add(name string, value any) {
+	switch existing := e.fields[name].(type) {
+	case nil:
+		e.fields[name] = value
+	case []any:
+		e.fields[name] = append(existing, value)
+	default:
+		e.fields[name] = []any{existing, value}
+	}
+}
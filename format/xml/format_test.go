@@ -0,0 +1,121 @@
+package xml
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParse_Success tests successful parsing of valid XML data.
+func TestParse_Success(t *testing.T) {
+	data := []byte("<person><name>Alice</name><age>30</age></person>")
+	parser := Xml{}
+	result, err := parser.Parse(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result == nil {
+		t.Fatalf("Expected non-nil result, got nil")
+	}
+
+	expectedMap := map[string]interface{}{
+		"name": "Alice",
+		"age":  "30",
+	}
+
+	if !reflect.DeepEqual(expectedMap, result.AsMap()) {
+		t.Errorf("Expected map %v, got %v", expectedMap, result.AsMap())
+	}
+}
+
+// TestParse_Mapping tests the mapping of elements, attributes and text.
+func TestParse_Mapping(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]interface{}
+	}{
+		{
+			name:  "Empty",
+			input: `<?xml version="1.0" encoding="UTF-8"?><config/>`,
+			want:  map[string]interface{}{},
+		},
+		{
+			name: "Nested",
+			input: `
+<config>
+  <!-- comment -->
+  <redis>
+    <addr>127.0.0.1:6379</addr>
+    <password><![CDATA[p<a>ss]]></password>
+    <empty/>
+  </redis>
+</config>`,
+			want: map[string]interface{}{
+				"redis": map[string]interface{}{"addr": "127.0.0.1:6379", "password": "p<a>ss", "empty": ""},
+			},
+		},
+		{
+			name:  "Repeated",
+			input: `<config><tag>a</tag><tag>b</tag><upstream><name>x</name></upstream><upstream><name>y</name></upstream><upstream><name>z</name></upstream></config>`,
+			want: map[string]interface{}{
+				"tag": []interface{}{"a", "b"},
+				"upstream": []interface{}{
+					map[string]interface{}{"name": "x"},
+					map[string]interface{}{"name": "y"},
+					map[string]interface{}{"name": "z"},
+				},
+			},
+		},
+		{
+			name:  "Attributes",
+			input: `<config version="2" xmlns="urn:config" xmlns:x="urn:x"><redis x:db="1" addr="127.0.0.1"/><port proto="tcp"> 8080 </port></config>`,
+			want: map[string]interface{}{
+				"version": "2",
+				"redis":   map[string]interface{}{"db": "1", "addr": "127.0.0.1"},
+				"port":    map[string]interface{}{"proto": "tcp", "#text": "8080"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Xml{}.Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(tt.want, result.AsMap()) {
+				t.Errorf("Expected map %v, got %v", tt.want, result.AsMap())
+			}
+		})
+	}
+}
+
+// TestParse_InvalidXML tests error handling for invalid XML format.
+func TestParse_InvalidXML(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "Unclosed", input: "<config>\n<name>Alice</config>", wantErr: "XML syntax error on line 2: element <name> closed by </config>"},
+		{name: "Empty", input: "", wantErr: "xml: no root element"},
+		{name: "MultipleRoots", input: "<a/>\n<b/>", wantErr: "xml: multiple root elements on line 2"},
+		{name: "AttributeAndElement", input: "<config>\n<redis db=\"1\">\n<db>2</db>\n</redis>\n</config>", wantErr: "xml: element <db> on line 3 has the name of an attribute of its parent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Xml{}.Parse([]byte(tt.input))
+			if err == nil {
+				t.Fatalf("Expected error, got nil")
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("Expected error %q, got %q", tt.wantErr, err)
+			}
+			if result != nil {
+				t.Errorf("Expected nil result, got %v", result)
+			}
+		})
+	}
+}
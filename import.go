@@ -5,19 +5,40 @@ import (
 	// Automatically registers env format decoder when imported
 	_ "github.com/go-leo/config/format/env"
 
+	// HCL format support
+	// Automatically registers hcl format decoder when imported
+	_ "github.com/go-leo/config/format/hcl"
+
+	// INI format support
+	// Automatically registers ini format decoder when imported
+	_ "github.com/go-leo/config/format/ini"
+
 	// JSON format support
 	// Automatically registers json format decoder when imported
 	_ "github.com/go-leo/config/format/json"
 
+	// Java properties format support
+	// Automatically registers properties format decoder when imported
+	_ "github.com/go-leo/config/format/properties"
+
 	// TOML format support
 	// Automatically registers toml format decoder when imported
 	_ "github.com/go-leo/config/format/toml"
 
+	// XML format support
+	// Automatically registers xml format decoder when imported
+	_ "github.com/go-leo/config/format/xml"
+
 	// YAML format support
 	// Automatically registers yaml format decoder when imported
 	_ "github.com/go-leo/config/format/yaml"
 
-	// Sample merger implementation
-	// Automatically registers sample merger when imported
-	_ "github.com/go-leo/config/merge/sample"
+	// Deep merger implementation
+	// Automatically registers deep merger when imported
+	_ "github.com/go-leo/config/merge/deep"
+
+	// File and environment variable secret resolvers
+	// Automatically registers the file and env secret schemes when imported
+	_ "github.com/go-leo/config/secret/env"
+	_ "github.com/go-leo/config/secret/file"
 )
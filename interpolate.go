@@ -0,0 +1,206 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// interpolator expands ${...} references in the string values of a merged
+// configuration. A reference ${name} or ${name:default} resolves to, in order:
+//   - the value at the key path name in the configuration, e.g. ${grpc.addr},
+//     list items are addressed by index, e.g. ${upstreams.0.addr}
+//   - the environment variable name, e.g. ${REDIS_HOST}
+//   - the default after the first ":", e.g. ${REDIS_HOST:localhost}
+//
+// A value that is exactly one reference to a key takes the referenced value
+// with its type, e.g. a number or a struct. "$${" is expanded to a literal "${".
+type interpolator struct {
+	// root is the configuration being expanded
+	root *structpb.Struct
+	// lookupEnv looks up environment variables
+	lookupEnv func(key string) (string, bool)
+	// resolving lists the key paths being expanded, to detect cycles
+	resolving []string
+	// done records the key paths already expanded
+	done map[string]bool
+}
+
+// interpolate returns a copy of value with every reference expanded.
+func interpolate(value *structpb.Struct, lookupEnv func(key string) (string, bool)) (*structpb.Struct, error) {
+	root := proto.Clone(value).(*structpb.Struct)
+	i := &interpolator{root: root, lookupEnv: lookupEnv, done: map[string]bool{}}
+	if err := i.walk("", structpb.NewStructValue(root)); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// walk expands the references in value, found at the key path path.
+func (i *interpolator) walk(path string, value *structpb.Value) error {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		if i.done[path] {
+			return nil
+		}
+		if err := i.enter(path); err != nil {
+			return err
+		}
+		expanded, err := i.expand(path, kind.StringValue)
+		i.leave()
+		if err != nil {
+			return err
+		}
+		value.Kind = expanded.GetKind()
+		i.done[path] = true
+	case *structpb.Value_StructValue:
+		keys := make([]string, 0, len(kind.StructValue.GetFields()))
+		for key := range kind.StructValue.GetFields() {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := i.walk(joinPath(path, key), kind.StructValue.GetFields()[key]); err != nil {
+				return err
+			}
+		}
+	case *structpb.Value_ListValue:
+		for index, item := range kind.ListValue.GetValues() {
+			if err := i.walk(joinPath(path, strconv.Itoa(index)), item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expand expands the references in s, the string at the key path path.
+func (i *interpolator) expand(path string, s string) (*structpb.Value, error) {
+	var b strings.Builder
+	for pos := 0; pos < len(s); {
+		if strings.HasPrefix(s[pos:], "$${") {
+			b.WriteString("${")
+			pos += 3
+			continue
+		}
+		if !strings.HasPrefix(s[pos:], "${") {
+			b.WriteByte(s[pos])
+			pos++
+			continue
+		}
+		end := strings.IndexByte(s[pos+2:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("config: unclosed reference in %s", path)
+		}
+		name, def, hasDef := strings.Cut(s[pos+2:pos+2+end], ":")
+		value, key, err := i.resolve(name)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			if !hasDef {
+				return nil, fmt.Errorf("config: unresolved reference ${%s} in %s", name, path)
+			}
+			value = structpb.NewStringValue(def)
+		}
+		next := pos + 3 + end
+		// a value that is exactly one reference to a key keeps its type
+		if key && pos == 0 && next == len(s) {
+			return proto.Clone(value).(*structpb.Value), nil
+		}
+		text, err := stringify(value)
+		if err != nil {
+			return nil, fmt.Errorf("config: cannot expand ${%s} in %s: %w", name, path, err)
+		}
+		b.WriteString(text)
+		pos = next
+	}
+	return structpb.NewStringValue(b.String()), nil
+}
+
+// resolve returns the value of the key path name with its references
+// expanded, or of the environment variable name. key reports whether the
+// value was found in the configuration. It returns nil if name is not found.
+func (i *interpolator) resolve(name string) (value *structpb.Value, key bool, err error) {
+	if value, ok := lookupPath(i.root, name); ok {
+		if err := i.walk(name, value); err != nil {
+			return nil, false, err
+		}
+		return value, true, nil
+	}
+	if env, ok := i.lookupEnv(name); ok {
+		return structpb.NewStringValue(env), false, nil
+	}
+	return nil, false, nil
+}
+
+// enter marks path as being expanded, it fails if path is already being
+// expanded, meaning references form a cycle.
+func (i *interpolator) enter(path string) error {
+	for index, resolving := range i.resolving {
+		if resolving == path {
+			cycle := append(append([]string{}, i.resolving[index:]...), path)
+			return fmt.Errorf("config: reference cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+	i.resolving = append(i.resolving, path)
+	return nil
+}
+
+// leave removes the last path entered.
+func (i *interpolator) leave() {
+	i.resolving = i.resolving[:len(i.resolving)-1]
+}
+
+// lookupPath returns the value at the key path path, keys are separated by
+// "." and list items are addressed by index.
+func lookupPath(root *structpb.Struct, path string) (*structpb.Value, bool) {
+	value := structpb.NewStructValue(root)
+	for _, key := range strings.Split(path, ".") {
+		switch kind := value.GetKind().(type) {
+		case *structpb.Value_StructValue:
+			field, ok := kind.StructValue.GetFields()[key]
+			if !ok {
+				return nil, false
+			}
+			value = field
+		case *structpb.Value_ListValue:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(kind.ListValue.GetValues()) {
+				return nil, false
+			}
+			value = kind.ListValue.GetValues()[index]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// joinPath appends key to the key path path.
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// stringify converts a scalar value into its text.
+func stringify(value *structpb.Value) (string, error) {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		return kind.StringValue, nil
+	case *structpb.Value_NumberValue:
+		return strconv.FormatFloat(kind.NumberValue, 'f', -1, 64), nil
+	case *structpb.Value_BoolValue:
+		return strconv.FormatBool(kind.BoolValue), nil
+	case *structpb.Value_NullValue:
+		return "", nil
+	default:
+		return "", fmt.Errorf("value is not a scalar")
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-leo/config/resource"
+	"github.com/go-leo/config/test"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestInterpolate(t *testing.T) {
+	env := map[string]string{"REDIS_PORT": "6379", "HOME": "/root"}
+	lookupEnv := func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+	tests := []struct {
+		name     string
+		value    map[string]interface{}
+		expected map[string]interface{}
+		err      string
+	}{
+		{
+			name:     "EnvAndDefault",
+			value:    map[string]interface{}{"addr": "${REDIS_HOST:localhost}:${REDIS_PORT}"},
+			expected: map[string]interface{}{"addr": "localhost:6379"},
+		},
+		{
+			name: "KeyReference",
+			value: map[string]interface{}{
+				"grpc":  map[string]interface{}{"addr": "10.0.0.1", "port": 9090},
+				"redis": map[string]interface{}{"addr": "${grpc.addr}:6379", "port": "${grpc.port}"},
+			},
+			expected: map[string]interface{}{
+				"grpc":  map[string]interface{}{"addr": "10.0.0.1", "port": 9090},
+				"redis": map[string]interface{}{"addr": "10.0.0.1:6379", "port": 9090},
+			},
+		},
+		{
+			name:     "Chain",
+			value:    map[string]interface{}{"a": "${b}", "b": "${c}-b", "c": "c"},
+			expected: map[string]interface{}{"a": "c-b", "b": "c-b", "c": "c"},
+		},
+		{
+			name:     "ListIndex",
+			value:    map[string]interface{}{"hosts": []interface{}{"a", "b"}, "host": "${hosts.1}"},
+			expected: map[string]interface{}{"hosts": []interface{}{"a", "b"}, "host": "b"},
+		},
+		{
+			name:     "Escape",
+			value:    map[string]interface{}{"a": "$${HOME}", "b": "${a}"},
+			expected: map[string]interface{}{"a": "${HOME}", "b": "${HOME}"},
+		},
+		{
+			name:  "Cycle",
+			value: map[string]interface{}{"a": "${b}", "b": "${a}"},
+			err:   "reference cycle: a -> b -> a",
+		},
+		{
+			name:  "SelfReference",
+			value: map[string]interface{}{"a": "x${a}"},
+			err:   "reference cycle: a -> a",
+		},
+		{
+			name:  "Unresolved",
+			value: map[string]interface{}{"a": "${REDIS_HOST}"},
+			err:   "unresolved reference ${REDIS_HOST} in a",
+		},
+		{
+			name:  "Unclosed",
+			value: map[string]interface{}{"a": "p@ss${REDIS_HOST"},
+			err:   "unclosed reference in a",
+		},
+		{
+			name:  "NotScalar",
+			value: map[string]interface{}{"grpc": map[string]interface{}{"addr": "x"}, "a": "x${grpc}"},
+			err:   "value is not a scalar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, _ := structpb.NewStruct(tt.value)
+			original := proto.Clone(value)
+			result, err := interpolate(value, lookupEnv)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expected error containing %q, got %v", tt.err, err)
+				}
+				// values may hold secrets, errors only name the path
+				if strings.Contains(err.Error(), "p@ss") {
+					t.Errorf("Expected error without the value, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			expected, _ := structpb.NewStruct(tt.expected)
+			if !proto.Equal(expected, result) {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+			if !proto.Equal(original, value) {
+				t.Errorf("Expected input to be unchanged, got %v", value)
+			}
+		})
+	}
+}
+
+func TestLoadInterpolation(t *testing.T) {
+	testStruct, _ := structpb.NewStruct(map[string]interface{}{"field1": "${HOST}", "field2": "$${HOST}"})
+	resources := []resource.Resource{&mockLoadResource{value: testStruct}}
+	lookupEnv := func(key string) (string, bool) { return "10.0.0.1", key == "HOST" }
+
+	result, err := Load[*test.Config](context.Background(), resources, WithInterpolation(true), WithLookupEnv(lookupEnv))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Field1 != "10.0.0.1" || result.Field2 != "${HOST}" {
+		t.Errorf("Expected '10.0.0.1' and '${HOST}', got '%s' and '%s'", result.Field1, result.Field2)
+	}
+
+	// disabled by default
+	result, err = Load[*test.Config](context.Background(), resources, WithLookupEnv(lookupEnv))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Field1 != "${HOST}" || result.Field2 != "$${HOST}" {
+		t.Errorf("Expected values to be kept, got '%s' and '%s'", result.Field1, result.Field2)
+	}
+}
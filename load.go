@@ -2,11 +2,12 @@ package config
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-leo/config/merge"
 	"github.com/go-leo/config/resource"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -17,26 +18,91 @@ import (
 // Parameters:
 //
 //	ctx context.Context - Context for controlling the loading process
-//	resources ...resource.Resource - Variadic parameter for multiple configuration resource loaders
+//	resources []resource.Resource - Configuration resource loaders, later resources take precedence
+//	opts ...Option - Options customizing merging and unmarshalling
 //
 // Returns:
 //
 //	Config - Successfully loaded and merged configuration object
 //	error - Any error encountered during loading or processing
-func Load[Config proto.Message](ctx context.Context, resources ...resource.Resource) (Config, error) {
+func Load[Config proto.Message](ctx context.Context, resources []resource.Resource, opts ...Option) (Config, error) {
+	config, _, err := load[Config](ctx, resources, newOptions(opts...))
+	return config, err
+}
+
+// load is the implementation of Load with options already resolved.
+// It also returns the values loaded from resources, so that Watch can
+// start from them instead of loading the resources again.
+func load[Config proto.Message](ctx context.Context, resources []resource.Resource, o *options) (Config, []merge.Layer, error) {
 	// 1. Sequentially load from all resources (return on first error)
-	var config Config
-	var values []*structpb.Struct
+	var layers []merge.Layer
 	for _, loader := range resources {
 		value, err := loader.Load(ctx)
 		if err != nil {
-			return config, err
+			var config Config
+			return config, nil, err
 		}
-		values = append(values, value)
+		layers = append(layers, merge.Layer{Source: resource.Name(loader), Value: value})
+	}
+	config, err := decode[Config](ctx, resources, layers, o)
+	return config, layers, err
+}
+
+// decode merges the layers loaded from resources and converts the result into a Config.
+func decode[Config proto.Message](ctx context.Context, resources []resource.Resource, layers []merge.Layer, o *options) (Config, error) {
+	var config Config
+	desc := config.ProtoReflect().Descriptor()
+
+	// Adapt the keys and values of every resource to the message, so that
+	// resources address the same fields by the same keys when merged
+	coerced := make([]merge.Layer, 0, len(layers)+1)
+	for i, layer := range layers {
+		c := coercion{loose: resource.IsLoose(resources[i]), blocks: resource.IsBlocks(resources[i])}
+		coerced = append(coerced, merge.Layer{Source: layer.Source, Value: coerce(desc, layer.Value, c)})
 	}
+	layers = coerced
 
 	// 2. Merge all loaded configurations using configured merger
-	value := merge.GetMerger().Merge(values...)
+	value, provenance, err := mergeLayers(o.getMerger(), desc, layers, o.provenanceHandler != nil)
+	if err != nil {
+		return config, err
+	}
+
+	// Default values declared in the proto fill the fields no resource sets.
+	// Defaults create the messages they are declared in, so whether a message
+	// is set is decided by the resources alone.
+	present := value
+	if defaults := Defaults(desc); len(defaults.GetFields()) > 0 {
+		value = proto.Clone(value).(*structpb.Struct)
+		fillDefaults(value, defaults, "", provenance)
+	}
+
+	merged := value
+
+	// Expand references to other keys and environment variables
+	if o.interpolation {
+		if value, err = interpolate(value, o.lookupEnv); err != nil {
+			return config, err
+		}
+	}
+
+	// Decrypt the ENC[...] values
+	if value, err = decrypt(value, o.decrypter); err != nil {
+		return config, err
+	}
+
+	// Replace secret references by the resolved secrets
+	if value, err = resolveSecrets(ctx, value); err != nil {
+		return config, err
+	}
+
+	// Adapt the values produced by interpolation, decryption and secrets
+	value = coerceExpanded(desc, value, merged)
+
+	// Every required field must be set by some resource
+	if err := checkRequired(desc, value, present); err != nil {
+		return config, err
+	}
 
 	// 3. Convert merged structpb.Struct to JSON format
 	data, err := value.MarshalJSON()
@@ -46,8 +112,37 @@ func Load[Config proto.Message](ctx context.Context, resources ...resource.Resou
 
 	// 4. Unmarshal JSON into target protobuf message
 	config = config.ProtoReflect().Type().New().Interface().(Config)
-	if err := protojson.Unmarshal(data, config); err != nil {
+	if err := o.unmarshalOptions.Unmarshal(data, config); err != nil {
 		return config, err
 	}
+
+	// 5. Validate the configuration
+	if err := validate(config, o); err != nil {
+		return config, err
+	}
+	if o.provenanceHandler != nil {
+		o.provenanceHandler(provenance)
+	}
 	return config, nil
 }
+
+// mergeLayers merges layers with the most capable interface the merger implements.
+// Provenance is only recorded when trace is true.
+func mergeLayers(merger merge.Merger, desc protoreflect.MessageDescriptor, layers []merge.Layer, trace bool) (*structpb.Struct, merge.Provenance, error) {
+	if trace {
+		tracer, ok := merger.(merge.Tracer)
+		if !ok {
+			return nil, nil, fmt.Errorf("config: merger %T does not support provenance", merger)
+		}
+		value, provenance := tracer.Trace(desc, layers...)
+		return value, provenance, nil
+	}
+	values := make([]*structpb.Struct, 0, len(layers))
+	for _, layer := range layers {
+		values = append(values, layer.Value)
+	}
+	if messageMerger, ok := merger.(merge.MessageMerger); ok {
+		return messageMerger.MergeMessage(desc, values...), nil, nil
+	}
+	return merger.Merge(values...), nil, nil
+}
@@ -3,16 +3,35 @@ package config
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/go-leo/config/merge"
+	"github.com/go-leo/config/resource"
+	"github.com/go-leo/config/resource/file"
 	"github.com/go-leo/config/test"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // 模拟resource.Resource接口
 type mockLoadResource struct {
-	value *structpb.Struct
-	err   error
+	value  *structpb.Struct
+	err    error
+	loose  bool
+	blocks bool
+}
+
+func (m *mockLoadResource) Loose() bool {
+	return m.loose
+}
+
+func (m *mockLoadResource) Blocks() bool {
+	return m.blocks
 }
 
 func (m *mockLoadResource) Load(ctx context.Context) (*structpb.Struct, error) {
@@ -39,7 +58,7 @@ func TestLoad(t *testing.T) {
 			value: testStruct,
 		}
 		ctx := context.Background()
-		result, err := Load[*test.Config](ctx, res)
+		result, err := Load[*test.Config](ctx, []resource.Resource{res})
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -61,7 +80,7 @@ func TestLoad(t *testing.T) {
 		res2 := &mockLoadResource{value: testStruct2}
 
 		ctx := context.Background()
-		result, err := Load[*test.Config](ctx, res1, res2)
+		result, err := Load[*test.Config](ctx, []resource.Resource{res1, res2})
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -78,7 +97,7 @@ func TestLoad(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		_, err := Load[*test.Config](ctx, res)
+		_, err := Load[*test.Config](ctx, []resource.Resource{res})
 		if err != expectedErr {
 			t.Errorf("Expected error '%v', got '%v'", expectedErr, err)
 		}
@@ -90,7 +109,7 @@ func TestLoad(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel()
 
-		_, err := Load[*test.Config](ctx, res)
+		_, err := Load[*test.Config](ctx, []resource.Resource{res})
 		if err != context.Canceled {
 			t.Errorf("Expected context.Canceled error, got '%v'", err)
 		}
@@ -108,7 +127,7 @@ func TestLoad(t *testing.T) {
 		res := &mockLoadResource{value: invalidStruct}
 
 		ctx := context.Background()
-		_, err := Load[*test.Config](ctx, res)
+		_, err := Load[*test.Config](ctx, []resource.Resource{res})
 		if err == nil {
 			t.Error("Expected JSON marshal error, got nil")
 		}
@@ -117,9 +136,219 @@ func TestLoad(t *testing.T) {
 	// 测试用例6: 空资源列表
 	t.Run("EmptyResources", func(t *testing.T) {
 		ctx := context.Background()
-		_, err := Load[*test.Config](ctx)
+		_, err := Load[*test.Config](ctx, nil)
 		if err != nil {
 			t.Errorf("Expected no error with empty resources, got %v", err)
 		}
 	})
 }
+
+// mergerFunc adapts a function to merge.Merger
+type mergerFunc func(values ...*structpb.Struct) *structpb.Struct
+
+func (f mergerFunc) Merge(values ...*structpb.Struct) *structpb.Struct {
+	return f(values...)
+}
+
+func TestLoadIncludeDelete(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"base.yaml":    "field1: a\nfield2: b",
+		"common.yaml":  "field1: c",
+		"overlay.yaml": "$include: [common.yaml]\nfield2: $delete",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var resources []resource.Resource
+	for _, name := range []string{"base.yaml", "overlay.yaml"} {
+		r, err := file.New(filepath.Join(tempDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resources = append(resources, r)
+	}
+
+	// the tombstone of the including file deletes field2 set by base.yaml
+	conf, err := Load[*test.Config](context.Background(), resources)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if conf.GetField1() != "c" || conf.GetField2() != "" {
+		t.Errorf("Expected field1 'c' and field2 deleted, got %v", conf)
+	}
+}
+
+func TestLoadOptions(t *testing.T) {
+	t.Run("WithMerger", func(t *testing.T) {
+		testStruct1, _ := structpb.NewStruct(map[string]interface{}{"field1": "value1"})
+		testStruct2, _ := structpb.NewStruct(map[string]interface{}{"field1": "value2"})
+		resources := []resource.Resource{&mockLoadResource{value: testStruct1}, &mockLoadResource{value: testStruct2}}
+
+		// merger that keeps the first value instead of the last one
+		first := mergerFunc(func(values ...*structpb.Struct) *structpb.Struct {
+			return values[0]
+		})
+		result, err := Load[*test.Config](context.Background(), resources, WithMerger(first))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Field1 != "value1" {
+			t.Errorf("Expected 'value1', got '%s'", result.Field1)
+		}
+	})
+
+	t.Run("WithUnmarshalOptions", func(t *testing.T) {
+		testStruct, _ := structpb.NewStruct(map[string]interface{}{
+			"field1":  "value1",
+			"unknown": "value",
+		})
+		resources := []resource.Resource{&mockLoadResource{value: testStruct}}
+
+		if _, err := Load[*test.Config](context.Background(), resources); err == nil {
+			t.Error("Expected unknown field error, got nil")
+		}
+		result, err := Load[*test.Config](context.Background(), resources, WithUnmarshalOptions(protojson.UnmarshalOptions{DiscardUnknown: true}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Field1 != "value1" {
+			t.Errorf("Expected 'value1', got '%s'", result.Field1)
+		}
+	})
+}
+
+func TestLoadMergeStrategies(t *testing.T) {
+	base, _ := structpb.NewStruct(map[string]interface{}{
+		"upstreams": []interface{}{
+			map[string]interface{}{"name": "a", "addr": "10.0.0.1", "weight": 1},
+			map[string]interface{}{"name": "b", "addr": "10.0.0.2", "weight": 1},
+		},
+	})
+	overlay, _ := structpb.NewStruct(map[string]interface{}{
+		"upstreams": []interface{}{
+			map[string]interface{}{"name": "b", "weight": 5},
+		},
+	})
+	resources := []resource.Resource{&mockLoadResource{value: base}, &mockLoadResource{value: overlay}}
+
+	result, err := Load[*test.Gateway](context.Background(), resources)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	upstreams := result.GetUpstreams()
+	if len(upstreams) != 2 {
+		t.Fatalf("Expected 2 upstreams, got %d", len(upstreams))
+	}
+	if upstreams[1].GetAddr() != "10.0.0.2" || upstreams[1].GetWeight() != 5 {
+		t.Errorf("Expected upstream b to be merged by name, got %v", upstreams[1])
+	}
+}
+
+// namedResource is a mockLoadResource with a name
+type namedResource struct {
+	mockLoadResource
+	name string
+}
+
+func (r *namedResource) String() string {
+	return r.name
+}
+
+func TestLoadProvenance(t *testing.T) {
+	testStruct1, _ := structpb.NewStruct(map[string]interface{}{"field1": "value1", "field2": "value2"})
+	testStruct2, _ := structpb.NewStruct(map[string]interface{}{"field2": "value3"})
+	resources := []resource.Resource{
+		&namedResource{mockLoadResource: mockLoadResource{value: testStruct1}, name: "file:config.yaml"},
+		&namedResource{mockLoadResource: mockLoadResource{value: testStruct2}, name: "env:APP_"},
+	}
+
+	var provenance merge.Provenance
+	_, err := Load[*test.Config](context.Background(), resources, WithProvenance(func(p merge.Provenance) {
+		provenance = p
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := merge.Provenance{
+		"field1": {Source: "file:config.yaml"},
+		"field2": {Source: "env:APP_", Overridden: []string{"file:config.yaml"}},
+	}
+	if !reflect.DeepEqual(expected, provenance) {
+		t.Errorf("Expected %v, got %v", expected, provenance)
+	}
+
+	// mergers without tracing support are rejected
+	first := mergerFunc(func(values ...*structpb.Struct) *structpb.Struct { return values[0] })
+	_, err = Load[*test.Config](context.Background(), resources, WithMerger(first), WithProvenance(func(merge.Provenance) {}))
+	if err == nil {
+		t.Error("Expected error for merger without provenance support, got nil")
+	}
+}
+
+// protoValidatorFunc adapts a function to ProtoValidator
+type protoValidatorFunc func(msg proto.Message) error
+
+func (f protoValidatorFunc) Validate(msg proto.Message) error {
+	return f(msg)
+}
+
+func TestLoadValidation(t *testing.T) {
+	testStruct, _ := structpb.NewStruct(map[string]interface{}{"field1": "value1"})
+	resources := []resource.Resource{&mockLoadResource{value: testStruct}}
+	invalid := errors.New("field2 is required")
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{
+			name: "Valid",
+			opts: []Option{WithValidator(func(c *test.Config) error { return nil })},
+		},
+		{
+			name: "Validator",
+			opts: []Option{WithValidator(func(c *test.Config) error {
+				if c.GetField2() == "" {
+					return invalid
+				}
+				return nil
+			})},
+			wantErr: invalid,
+		},
+		{
+			name: "ProtoValidator",
+			opts: []Option{WithProtoValidator(protoValidatorFunc(func(msg proto.Message) error {
+				return invalid
+			}))},
+			wantErr: invalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Load[*test.Config](context.Background(), resources, tt.opts...)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if result.Field1 != "value1" {
+					t.Errorf("Expected 'value1', got '%s'", result.Field1)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected validation error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+	t.Run("TypeMismatch", func(t *testing.T) {
+		_, err := Load[*test.Config](context.Background(), resources, WithValidator(func(c *test.Server) error { return nil }))
+		if err == nil || !strings.Contains(err.Error(), "validator for *test.Server applied to *test.Config") {
+			t.Errorf("Expected type mismatch error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,328 @@
+package deep
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-leo/config/merge"
+	"github.com/go-leo/config/proto/leo/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Merger implements the Merger, MessageMerger and Tracer interfaces.
+// Unlike the sample merger, nested structs are merged key by key instead of
+// being replaced, so a later resource only overrides the keys it sets.
+// When the target message descriptor is known, repeated fields are merged
+// according to their (leo.config.merge) option.
+// Deletion follows the rules documented on merge.Delete.
+type Merger struct{}
+
+func init() {
+	merge.SetMerger(Merger{})
+}
+
+// Merge combines multiple structpb.Struct values into a single struct
+// It creates a new target struct and deeply merges all source structs into it,
+// later values take precedence over earlier ones
+func (m Merger) Merge(values ...*structpb.Struct) *structpb.Struct {
+	return m.MergeMessage(nil, values...)
+}
+
+// MergeMessage works like Merge, and additionally uses desc to look up the
+// merge strategy of repeated fields. A nil desc merges lists by replacement.
+func (m Merger) MergeMessage(desc protoreflect.MessageDescriptor, values ...*structpb.Struct) *structpb.Struct {
+	s := &state{}
+	target := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for _, value := range values {
+		s.mergeStruct(desc, target, value, "")
+	}
+	return target
+}
+
+// Trace works like MergeMessage and records which layer supplied each value.
+func (m Merger) Trace(desc protoreflect.MessageDescriptor, layers ...merge.Layer) (*structpb.Struct, merge.Provenance) {
+	s := &state{provenance: merge.Provenance{}}
+	target := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for _, layer := range layers {
+		s.source = layer.Source
+		s.mergeStruct(desc, target, layer.Value, "")
+	}
+	return target, s.provenance
+}
+
+// state holds the bookkeeping of a single merge
+type state struct {
+	// provenance collects origins of values, nil when not tracing
+	provenance merge.Provenance
+	// source name of the layer being merged
+	source string
+}
+
+// mergeStruct merges fields from source struct into target struct
+// desc describes the message the struct is unmarshalled into, may be nil
+func (s *state) mergeStruct(desc protoreflect.MessageDescriptor, target *structpb.Struct, source *structpb.Struct, path string) {
+	for key, field := range source.GetFields() {
+		s.mergeField(findField(desc, key), target, key, field, joinKey(path, key))
+	}
+}
+
+// mergeMap merges entries from source struct into target struct
+// fd is the map field the struct is unmarshalled into
+func (s *state) mergeMap(fd protoreflect.FieldDescriptor, target *structpb.Struct, source *structpb.Struct, path string) {
+	for key, field := range source.GetFields() {
+		s.mergeField(fd.MapValue(), target, key, field, joinKey(path, key))
+	}
+}
+
+// mergeField merges a single source value into target under key
+// Nested structs present on both sides are merged recursively, lists are
+// merged by the strategy of fd, any other value in source replaces the value
+// in target. fd may be nil when the field is unknown.
+func (s *state) mergeField(fd protoreflect.FieldDescriptor, target *structpb.Struct, key string, field *structpb.Value, path string) {
+	if merge.IsDelete(field) {
+		delete(target.Fields, key)
+		s.forget(path, true)
+		return
+	}
+	targetValue, ok := target.Fields[key]
+	if !ok {
+		target.Fields[key] = copyValue(field)
+		s.recordValue(fd, target.Fields[key], path)
+		return
+	}
+	if targetStruct, sourceStruct := targetValue.GetStructValue(), field.GetStructValue(); targetStruct != nil && sourceStruct != nil {
+		if fd != nil && fd.IsMap() {
+			s.mergeMap(fd, targetStruct, sourceStruct, path)
+			return
+		}
+		s.mergeStruct(messageDescriptor(fd), targetStruct, sourceStruct, path)
+		return
+	}
+	if targetList, sourceList := targetValue.GetListValue(), field.GetListValue(); targetList != nil && sourceList != nil && fd != nil && fd.IsList() {
+		s.mergeList(fd, targetList, sourceList, path)
+		return
+	}
+	target.Fields[key] = copyValue(field)
+	s.forget(path, field.GetStructValue() != nil)
+	s.recordValue(fd, target.Fields[key], path)
+}
+
+// mergeList merges values from source ListValue into target ListValue
+// following the (leo.config.merge) option of fd
+func (s *state) mergeList(fd protoreflect.FieldDescriptor, target *structpb.ListValue, source *structpb.ListValue, path string) {
+	switch strategy(fd) {
+	case config.MergeStrategy_APPEND:
+		for _, item := range source.GetValues() {
+			target.Values = append(target.Values, copyValue(item))
+		}
+		s.record(path)
+	case config.MergeStrategy_MERGE_BY_KEY:
+		keyField := keyDescriptor(fd)
+		for _, item := range source.GetValues() {
+			index := findItem(keyField, target, item)
+			if index < 0 {
+				target.Values = append(target.Values, copyValue(item))
+				index = len(target.Values) - 1
+				if s.provenance != nil {
+					s.recordStruct(fd.Message(), target.Values[index].GetStructValue(), indexPath(path, index))
+				}
+				continue
+			}
+			s.mergeStruct(fd.Message(), target.Values[index].GetStructValue(), item.GetStructValue(), indexPath(path, index))
+		}
+	default:
+		target.Values = copyList(source).GetValues()
+		s.forget(path, false)
+		s.recordValue(fd, structpb.NewListValue(target), path)
+	}
+}
+
+// recordValue records the current source for every leaf of value at path.
+// fd describes value, may be nil
+func (s *state) recordValue(fd protoreflect.FieldDescriptor, value *structpb.Value, path string) {
+	if s.provenance == nil {
+		return
+	}
+	switch v := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		if fd != nil && fd.IsMap() {
+			for key, field := range v.StructValue.GetFields() {
+				s.recordValue(fd.MapValue(), field, joinKey(path, key))
+			}
+			return
+		}
+		s.recordStruct(messageDescriptor(fd), v.StructValue, path)
+	case *structpb.Value_ListValue:
+		if fd == nil || !fd.IsList() || strategy(fd) != config.MergeStrategy_MERGE_BY_KEY {
+			s.record(path)
+			return
+		}
+		for index, item := range v.ListValue.GetValues() {
+			if item.GetStructValue() == nil {
+				s.record(indexPath(path, index))
+				continue
+			}
+			s.recordStruct(fd.Message(), item.GetStructValue(), indexPath(path, index))
+		}
+	default:
+		s.record(path)
+	}
+}
+
+// recordStruct records the current source for every leaf of a struct at path.
+// desc describes the struct, may be nil
+func (s *state) recordStruct(desc protoreflect.MessageDescriptor, value *structpb.Struct, path string) {
+	for key, field := range value.GetFields() {
+		s.recordValue(findField(desc, key), field, joinKey(path, key))
+	}
+}
+
+// record records the current source for a leaf at path, and moves the
+// previous origin of the path to the overridden list.
+func (s *state) record(path string) {
+	if s.provenance == nil {
+		return
+	}
+	origin := &merge.Origin{Source: s.source}
+	if previous, ok := s.provenance[path]; ok {
+		origin.Overridden = append(append(origin.Overridden, previous.Overridden...), previous.Source)
+	}
+	s.provenance[path] = origin
+}
+
+// forget removes the origins of every value nested below path,
+// and of path itself if self is true.
+func (s *state) forget(path string, self bool) {
+	for p := range s.provenance {
+		if (self && p == path) || strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+			delete(s.provenance, p)
+		}
+	}
+}
+
+// joinKey appends key to the path of its parent
+func joinKey(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// indexPath appends a list index to the path of the list
+func indexPath(path string, index int) string {
+	return path + "[" + strconv.Itoa(index) + "]"
+}
+
+// copyList creates a deep copy of a protobuf ListValue
+// Tombstones are removed from the copy
+func copyList(list *structpb.ListValue) *structpb.ListValue {
+	list = proto.Clone(list).(*structpb.ListValue)
+	removeTombstones(structpb.NewListValue(list))
+	return list
+}
+
+// copyValue creates a deep copy of a protobuf Value
+// Tombstones are removed from the copy, returns NullValue for nil
+func copyValue(value *structpb.Value) *structpb.Value {
+	if value == nil || value.GetKind() == nil {
+		return structpb.NewNullValue()
+	}
+	value = proto.Clone(value).(*structpb.Value)
+	removeTombstones(value)
+	return value
+}
+
+// removeTombstones deletes every key holding the merge.Delete tombstone
+// from the structs nested in value, since there is nothing left to delete.
+func removeTombstones(value *structpb.Value) {
+	switch v := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		for key, field := range v.StructValue.GetFields() {
+			if merge.IsDelete(field) {
+				delete(v.StructValue.Fields, key)
+				continue
+			}
+			removeTombstones(field)
+		}
+	case *structpb.Value_ListValue:
+		for _, item := range v.ListValue.GetValues() {
+			removeTombstones(item)
+		}
+	}
+}
+
+// strategy returns the (leo.config.merge) option of fd.
+// MERGE_BY_KEY falls back to REPLACE when the key field cannot be resolved.
+func strategy(fd protoreflect.FieldDescriptor) config.MergeStrategy {
+	s := proto.GetExtension(fd.Options(), config.E_Merge).(config.MergeStrategy)
+	if s == config.MergeStrategy_MERGE_BY_KEY && keyDescriptor(fd) == nil {
+		return config.MergeStrategy_REPLACE
+	}
+	return s
+}
+
+// findField looks up the field named key in desc, by proto name or JSON name.
+// Returns nil if desc is nil or has no such field.
+func findField(desc protoreflect.MessageDescriptor, key string) protoreflect.FieldDescriptor {
+	if desc == nil {
+		return nil
+	}
+	if fd := desc.Fields().ByName(protoreflect.Name(key)); fd != nil {
+		return fd
+	}
+	return desc.Fields().ByJSONName(key)
+}
+
+// messageDescriptor returns the descriptor of the message a struct value of
+// fd is unmarshalled into. Returns nil for unknown, list and map fields.
+func messageDescriptor(fd protoreflect.FieldDescriptor) protoreflect.MessageDescriptor {
+	if fd == nil || fd.IsList() || fd.IsMap() {
+		return nil
+	}
+	return fd.Message()
+}
+
+// keyDescriptor returns the field named by the (leo.config.merge_key) option of fd.
+// Returns nil if fd is not a list of messages or the key field does not exist.
+func keyDescriptor(fd protoreflect.FieldDescriptor) protoreflect.FieldDescriptor {
+	if !fd.IsList() || fd.Message() == nil {
+		return nil
+	}
+	key := proto.GetExtension(fd.Options(), config.E_MergeKey).(string)
+	if key == "" {
+		return nil
+	}
+	return findField(fd.Message(), key)
+}
+
+// findItem returns the index of the struct in list whose key field equals
+// the key field of item. Returns -1 if item has no key or no element matches.
+func findItem(keyField protoreflect.FieldDescriptor, list *structpb.ListValue, item *structpb.Value) int {
+	key := itemKey(keyField, item.GetStructValue())
+	if key == nil {
+		return -1
+	}
+	for index, targetItem := range list.GetValues() {
+		targetKey := itemKey(keyField, targetItem.GetStructValue())
+		if targetKey != nil && proto.Equal(key, targetKey) {
+			return index
+		}
+	}
+	return -1
+}
+
+// itemKey returns the value of the key field in the struct, by proto name or JSON name.
+func itemKey(keyField protoreflect.FieldDescriptor, item *structpb.Struct) *structpb.Value {
+	if item == nil {
+		return nil
+	}
+	if value, ok := item.GetFields()[string(keyField.Name())]; ok {
+		return value
+	}
+	if value, ok := item.GetFields()[keyField.JSONName()]; ok {
+		return value
+	}
+	return nil
+}
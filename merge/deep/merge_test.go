@@ -0,0 +1,306 @@
+package deep
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-leo/config/format/env"
+	"github.com/go-leo/config/format/json"
+	"github.com/go-leo/config/format/yaml"
+	"github.com/go-leo/config/merge"
+	"github.com/go-leo/config/test"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestMergeStructs(t *testing.T) {
+	m := Merger{}
+
+	s1, err := structpb.NewStruct(map[string]interface{}{
+		"name": "John",
+		"age":  30,
+		"address": map[string]interface{}{
+			"city": "New York",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test struct: %v", err)
+	}
+
+	s2, err := structpb.NewStruct(map[string]interface{}{
+		"age": 31,
+		"address": map[string]interface{}{
+			"zip": "10001",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test struct: %v", err)
+	}
+
+	result := m.Merge(s1, s2)
+
+	expected := map[string]interface{}{
+		"name": "John",
+		"age":  float64(31),
+		"address": map[string]interface{}{
+			"city": "New York",
+			"zip":  "10001",
+		},
+	}
+	if !reflect.DeepEqual(expected, result.AsMap()) {
+		t.Errorf("Expected %v, got %v", expected, result.AsMap())
+	}
+}
+
+func TestMergeReplacesNonStruct(t *testing.T) {
+	m := Merger{}
+
+	s1, _ := structpb.NewStruct(map[string]interface{}{
+		"list":   []interface{}{1, 2},
+		"object": map[string]interface{}{"key": "value"},
+		"scalar": map[string]interface{}{"key": "value"},
+	})
+	s2, _ := structpb.NewStruct(map[string]interface{}{
+		"list":   []interface{}{3},
+		"object": nil,
+		"scalar": "value",
+	})
+
+	result := m.Merge(s1, s2)
+
+	expected := map[string]interface{}{
+		"list":   []interface{}{float64(3)},
+		"object": nil,
+		"scalar": "value",
+	}
+	if !reflect.DeepEqual(expected, result.AsMap()) {
+		t.Errorf("Expected %v, got %v", expected, result.AsMap())
+	}
+}
+
+func TestMergeDoesNotModifyInputs(t *testing.T) {
+	m := Merger{}
+
+	s1, _ := structpb.NewStruct(map[string]interface{}{
+		"redis": map[string]interface{}{"addr": "127.0.0.1:6379"},
+	})
+	s2, _ := structpb.NewStruct(map[string]interface{}{
+		"redis": map[string]interface{}{"db": 1},
+	})
+
+	result := m.Merge(s1, s2)
+	result.GetFields()["redis"].GetStructValue().GetFields()["addr"] = structpb.NewStringValue("changed")
+
+	if len(s1.GetFields()["redis"].GetStructValue().GetFields()) != 1 {
+		t.Errorf("Expected first input to be untouched, got %v", s1.AsMap())
+	}
+	if s1.GetFields()["redis"].GetStructValue().GetFields()["addr"].GetStringValue() != "127.0.0.1:6379" {
+		t.Errorf("Expected first input to be untouched, got %v", s1.AsMap())
+	}
+	if len(s2.GetFields()["redis"].GetStructValue().GetFields()) != 1 {
+		t.Errorf("Expected second input to be untouched, got %v", s2.AsMap())
+	}
+}
+
+// TestMergeOverlays merges env, json and yaml resources the way example/cmd/main.go layers them.
+func TestMergeOverlays(t *testing.T) {
+	m := Merger{}
+
+	envValue, err := env.Env{}.Parse([]byte("LEO_RUN_ENV=dev"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonValue, err := json.Json{}.Parse([]byte(`{
+		"grpc": {"addr": "127.0.0.1", "port": 8080},
+		"redis": {"addr": "127.0.0.1:6379", "pool": {"size": 10, "timeout": {"dial": "1s", "read": "2s"}}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	yamlValue, err := yaml.Yaml{}.Parse([]byte(`
+redis:
+  db: 3
+  pool:
+    timeout:
+      read: 5s
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := m.Merge(envValue, jsonValue, yamlValue)
+
+	expected := map[string]interface{}{
+		"LEO_RUN_ENV": "dev",
+		"grpc": map[string]interface{}{
+			"addr": "127.0.0.1",
+			"port": float64(8080),
+		},
+		"redis": map[string]interface{}{
+			"addr": "127.0.0.1:6379",
+			"db":   float64(3),
+			"pool": map[string]interface{}{
+				"size": float64(10),
+				"timeout": map[string]interface{}{
+					"dial": "1s",
+					"read": "5s",
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result.AsMap()) {
+		t.Errorf("Expected %v, got %v", expected, result.AsMap())
+	}
+}
+
+func TestMergeMessageListStrategies(t *testing.T) {
+	m := Merger{}
+	desc := (&test.Gateway{}).ProtoReflect().Descriptor()
+
+	base, _ := structpb.NewStruct(map[string]interface{}{
+		"upstreams": []interface{}{
+			map[string]interface{}{"name": "a", "addr": "10.0.0.1", "weight": 1, "tags": []interface{}{"x"}},
+			map[string]interface{}{"name": "b", "addr": "10.0.0.2", "weight": 1},
+		},
+		"tags":   []interface{}{"base"},
+		"hosts":  []interface{}{"base.example.com"},
+		"labels": []interface{}{"base"},
+		"routes": map[string]interface{}{
+			"/": map[string]interface{}{"name": "a", "tags": []interface{}{"x"}},
+		},
+	})
+	overlay, _ := structpb.NewStruct(map[string]interface{}{
+		"upstreams": []interface{}{
+			map[string]interface{}{"name": "b", "weight": 5},
+			map[string]interface{}{"name": "c", "addr": "10.0.0.3"},
+			map[string]interface{}{"name": "a", "tags": []interface{}{"y"}},
+		},
+		"tags":   []interface{}{"overlay"},
+		"hosts":  []interface{}{"overlay.example.com"},
+		"labels": []interface{}{"overlay"},
+		"routes": map[string]interface{}{
+			"/": map[string]interface{}{"tags": []interface{}{"y"}},
+		},
+	})
+
+	result := m.MergeMessage(desc, base, overlay)
+
+	expected := map[string]interface{}{
+		"upstreams": []interface{}{
+			map[string]interface{}{"name": "a", "addr": "10.0.0.1", "weight": float64(1), "tags": []interface{}{"x", "y"}},
+			map[string]interface{}{"name": "b", "addr": "10.0.0.2", "weight": float64(5)},
+			map[string]interface{}{"name": "c", "addr": "10.0.0.3"},
+		},
+		"tags":   []interface{}{"base", "overlay"},
+		"hosts":  []interface{}{"overlay.example.com"},
+		"labels": []interface{}{"overlay"},
+		"routes": map[string]interface{}{
+			"/": map[string]interface{}{"name": "a", "tags": []interface{}{"x", "y"}},
+		},
+	}
+	if !reflect.DeepEqual(expected, result.AsMap()) {
+		t.Errorf("Expected %v, got %v", expected, result.AsMap())
+	}
+
+	// without a descriptor every list is replaced
+	result = m.Merge(base, overlay)
+	if got := len(result.GetFields()["upstreams"].GetListValue().GetValues()); got != 3 {
+		t.Errorf("Expected 3 upstreams, got %d", got)
+	}
+	if got := len(result.GetFields()["tags"].GetListValue().GetValues()); got != 1 {
+		t.Errorf("Expected 1 tag, got %d", got)
+	}
+}
+
+func TestMergeDelete(t *testing.T) {
+	m := Merger{}
+
+	base, _ := structpb.NewStruct(map[string]interface{}{
+		"redis": map[string]interface{}{
+			"addr":     "127.0.0.1:6379",
+			"password": "secret",
+			"db":       1,
+		},
+		"grpc": map[string]interface{}{"addr": "127.0.0.1"},
+	})
+	overlay, _ := structpb.NewStruct(map[string]interface{}{
+		"redis": map[string]interface{}{
+			"password": "$delete",
+			"db":       nil,
+			"network":  "$delete",
+		},
+		"grpc": "$delete",
+		"new": map[string]interface{}{
+			"key":     "value",
+			"removed": "$delete",
+		},
+	})
+
+	result := m.Merge(base, overlay)
+
+	expected := map[string]interface{}{
+		"redis": map[string]interface{}{
+			"addr": "127.0.0.1:6379",
+			"db":   nil,
+		},
+		"new": map[string]interface{}{"key": "value"},
+	}
+	if !reflect.DeepEqual(expected, result.AsMap()) {
+		t.Errorf("Expected %v, got %v", expected, result.AsMap())
+	}
+
+	// explicit null is present, deleted key is absent
+	if v, ok := merge.Lookup(result, "redis", "db"); !ok || !merge.IsNull(v) {
+		t.Errorf("Expected redis.db to be explicit null, got %v, %v", v, ok)
+	}
+	if _, ok := merge.Lookup(result, "redis", "password"); ok {
+		t.Error("Expected redis.password to be absent")
+	}
+}
+
+func TestTrace(t *testing.T) {
+	m := Merger{}
+	desc := (&test.Gateway{}).ProtoReflect().Descriptor()
+
+	base, _ := structpb.NewStruct(map[string]interface{}{
+		"upstreams": []interface{}{
+			map[string]interface{}{"name": "a", "addr": "10.0.0.1"},
+			map[string]interface{}{"name": "b", "addr": "10.0.0.2"},
+		},
+		"tags":   []interface{}{"base"},
+		"hosts":  []interface{}{"base.example.com"},
+		"labels": []interface{}{"base"},
+	})
+	env, _ := structpb.NewStruct(map[string]interface{}{
+		"upstreams": []interface{}{
+			map[string]interface{}{"name": "b", "addr": "10.0.1.2"},
+		},
+		"tags":   []interface{}{"env"},
+		"labels": "$delete",
+	})
+	overlay, _ := structpb.NewStruct(map[string]interface{}{
+		"upstreams": []interface{}{
+			map[string]interface{}{"name": "b", "addr": "10.0.2.2"},
+			map[string]interface{}{"name": "c", "addr": "10.0.2.3"},
+		},
+	})
+
+	_, provenance := m.Trace(desc,
+		merge.Layer{Source: "file:base.yaml", Value: base},
+		merge.Layer{Source: "env:APP_", Value: env},
+		merge.Layer{Source: "file:overlay.yaml", Value: overlay},
+	)
+
+	expected := merge.Provenance{
+		"upstreams[0].name": {Source: "file:base.yaml"},
+		"upstreams[0].addr": {Source: "file:base.yaml"},
+		"upstreams[1].name": {Source: "file:overlay.yaml", Overridden: []string{"file:base.yaml", "env:APP_"}},
+		"upstreams[1].addr": {Source: "file:overlay.yaml", Overridden: []string{"file:base.yaml", "env:APP_"}},
+		"upstreams[2].name": {Source: "file:overlay.yaml"},
+		"upstreams[2].addr": {Source: "file:overlay.yaml"},
+		"tags":              {Source: "env:APP_", Overridden: []string{"file:base.yaml"}},
+		"hosts":             {Source: "file:base.yaml"},
+	}
+	if !reflect.DeepEqual(expected, provenance) {
+		t.Errorf("Expected\n%v\ngot\n%v", expected, provenance)
+	}
+}
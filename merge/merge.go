@@ -3,6 +3,7 @@ package merge
 import (
 	"sync"
 
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -11,12 +12,33 @@ var (
 	mutex  sync.RWMutex
 )
 
+// Delete is the tombstone value that removes a key when merging.
+//
+// Mergers honouring deletion follow these rules:
+//   - a key whose value is the string "$delete" removes the key set by earlier
+//     values, the key is absent from the merged result
+//   - a key whose value is null overrides earlier values and stays in the merged
+//     result as an explicit null, which protojson treats as the default value
+//
+// Use Lookup together with IsNull to tell an explicit null from an absent key.
+const Delete = "$delete"
+
 // Merger defines an interface for merging multiple protobuf Structs into one
 type Merger interface {
 	// Merge combines multiple structpb.Struct values into a single struct
 	Merge(values ...*structpb.Struct) *structpb.Struct
 }
 
+// MessageMerger is an optional interface implemented by mergers that consult
+// the descriptor of the target message, e.g. to honour field options such as
+// (leo.config.merge). config.Load prefers MergeMessage when it is available.
+type MessageMerger interface {
+	Merger
+	// MergeMessage combines multiple structpb.Struct values that will be
+	// unmarshalled into a message described by desc
+	MergeMessage(desc protoreflect.MessageDescriptor, values ...*structpb.Struct) *structpb.Struct
+}
+
 // SetMerger sets the global merger instance with thread-safe protection.
 // This function uses a mutex to ensure atomic write operations when updating
 // the shared 'merger' variable.
@@ -48,3 +70,36 @@ func GetMerger() Merger {
 	mutex.RUnlock()
 	return m
 }
+
+// IsDelete reports whether value is the Delete tombstone.
+func IsDelete(value *structpb.Value) bool {
+	v, ok := value.GetKind().(*structpb.Value_StringValue)
+	return ok && v.StringValue == Delete
+}
+
+// IsNull reports whether value is an explicit null.
+func IsNull(value *structpb.Value) bool {
+	_, ok := value.GetKind().(*structpb.Value_NullValue)
+	return ok
+}
+
+// Lookup returns the value found by following keys through nested structs.
+// The boolean reports whether the key is present, so an explicit null
+// (present, IsNull) can be distinguished from an absent key.
+func Lookup(value *structpb.Struct, keys ...string) (*structpb.Value, bool) {
+	var current *structpb.Value
+	for i, key := range keys {
+		if i > 0 {
+			value = current.GetStructValue()
+		}
+		if value == nil {
+			return nil, false
+		}
+		v, ok := value.GetFields()[key]
+		if !ok {
+			return nil, false
+		}
+		current = v
+	}
+	return current, current != nil
+}
@@ -0,0 +1,57 @@
+package merge
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestLookup(t *testing.T) {
+	value, _ := structpb.NewStruct(map[string]interface{}{
+		"redis": map[string]interface{}{
+			"addr": "127.0.0.1:6379",
+			"db":   nil,
+		},
+	})
+
+	tests := []struct {
+		name     string
+		keys     []string
+		present  bool
+		null     bool
+		expected string
+	}{
+		{name: "Nested", keys: []string{"redis", "addr"}, present: true, expected: "127.0.0.1:6379"},
+		{name: "ExplicitNull", keys: []string{"redis", "db"}, present: true, null: true},
+		{name: "Absent", keys: []string{"redis", "password"}},
+		{name: "ThroughScalar", keys: []string{"redis", "addr", "host"}},
+		{name: "Empty", keys: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := Lookup(value, tt.keys...)
+			if ok != tt.present {
+				t.Fatalf("Expected present %v, got %v", tt.present, ok)
+			}
+			if IsNull(v) != tt.null {
+				t.Errorf("Expected null %v, got %v", tt.null, IsNull(v))
+			}
+			if v.GetStringValue() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, v.GetStringValue())
+			}
+		})
+	}
+}
+
+func TestIsDelete(t *testing.T) {
+	if !IsDelete(structpb.NewStringValue(Delete)) {
+		t.Error("Expected tombstone to be detected")
+	}
+	if IsDelete(structpb.NewStringValue("delete")) {
+		t.Error("Expected plain string not to be a tombstone")
+	}
+	if IsDelete(nil) {
+		t.Error("Expected nil not to be a tombstone")
+	}
+}
@@ -0,0 +1,79 @@
+package merge
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Layer is a value to be merged, tagged with the name of the resource that supplied it.
+type Layer struct {
+	// Source name of the resource, e.g. file:/etc/app/config.yaml
+	Source string
+	// Value loaded from the resource
+	Value *structpb.Struct
+}
+
+// Origin describes where an effective value comes from.
+type Origin struct {
+	// Source is the resource that supplied the effective value
+	Source string `json:"source"`
+	// Overridden lists the resources whose values for the same path were
+	// overridden (or appended to), in merge order
+	Overridden []string `json:"overridden,omitempty"`
+}
+
+// Provenance maps the path of every effective value to its origin.
+// Paths join struct keys with "." and list indexes with "[i]",
+// e.g. redis.addr or upstreams[1].weight.
+type Provenance map[string]*Origin
+
+// Tracer is an optional interface implemented by mergers that can report
+// the provenance of every merged value.
+type Tracer interface {
+	// Trace merges layers like MessageMerger.MergeMessage and returns the
+	// provenance of the values in the result. desc may be nil.
+	Trace(desc protoreflect.MessageDescriptor, layers ...Layer) (*structpb.Struct, Provenance)
+}
+
+// Paths returns the sorted paths of p.
+func (p Provenance) Paths() []string {
+	paths := make([]string, 0, len(p))
+	for path := range p {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// String returns one line per path in the form "path -> source (overrides a, b)".
+func (p Provenance) String() string {
+	var b strings.Builder
+	for _, path := range p.Paths() {
+		origin := p[path]
+		b.WriteString(path)
+		b.WriteString(" -> ")
+		b.WriteString(origin.Source)
+		if len(origin.Overridden) > 0 {
+			b.WriteString(" (overrides ")
+			b.WriteString(strings.Join(origin.Overridden, ", "))
+			b.WriteString(")")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// ServeHTTP writes p as JSON, so it can be mounted as a debug endpoint.
+func (p Provenance) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(p); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
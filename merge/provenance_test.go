@@ -0,0 +1,39 @@
+package merge
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestProvenanceString(t *testing.T) {
+	p := Provenance{
+		"redis.db":   {Source: "file:config.dev.yaml", Overridden: []string{"file:config.yaml"}},
+		"redis.addr": {Source: "file:config.yaml"},
+	}
+	expected := "redis.addr -> file:config.yaml\n" +
+		"redis.db -> file:config.dev.yaml (overrides file:config.yaml)\n"
+	if got := p.String(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestProvenanceServeHTTP(t *testing.T) {
+	p := Provenance{
+		"redis.addr": {Source: "file:config.yaml"},
+	}
+	recorder := httptest.NewRecorder()
+	p.ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/config/provenance", nil))
+
+	if got := recorder.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Expected application/json, got %q", got)
+	}
+	var got Provenance
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p, got) {
+		t.Errorf("Expected %v, got %v", p, got)
+	}
+}
@@ -1,17 +1,16 @@
 package sample
 
 import (
-	"github.com/go-leo/config/merge"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // Merger implements the Merger interface
+// Top-level keys of later values replace earlier ones as a whole, nested structs
+// are not merged. It is kept for backward compatibility, see the deep package
+// for the default merger. Importing this package does not change the global
+// merger, pass it with config.WithMerger instead.
 type Merger struct{}
 
-func init() {
-	merge.SetMerger(Merger{})
-}
-
 // Merge combines multiple structpb.Struct values into a single struct
 // It creates a new target struct and merges all source structs into it
 func (m Merger) Merge(values ...*structpb.Struct) *structpb.Struct {
@@ -3,9 +3,17 @@ package sample
 import (
 	"testing"
 
+	"github.com/go-leo/config/merge"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// TestNotRegistered tests that importing the package keeps the global merger.
+func TestNotRegistered(t *testing.T) {
+	if _, ok := merge.GetMerger().(Merger); ok {
+		t.Errorf("Expected the sample merger not to be registered as the global merger")
+	}
+}
+
 func TestMergeStructs(t *testing.T) {
 	m := Merger{}
 
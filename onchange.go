@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// OnChange registers fn to be called when the field at path changed in an
+// update of store, with the old and new value of that field. path uses proto
+// field names joined by ".", e.g. "redis" or "redis.addr", and must name a
+// singular field whose Go type is Field, e.g. *Redis or string.
+// An unset message field is passed as nil.
+//
+// The path is resolved when registering, an error is returned if it does not
+// exist or does not match Field. The returned function removes the subscription.
+func OnChange[Config proto.Message, Field any](store *Store[Config], path string, fn func(old, new Field)) (func(), error) {
+	var config Config
+	fields, err := resolvePath(config.ProtoReflect().Descriptor(), path)
+	if err != nil {
+		return nil, err
+	}
+	leaf := fields[len(fields)-1]
+	if _, ok := zeroFieldValue(leaf).(Field); !ok {
+		var field Field
+		return nil, fmt.Errorf("config: field %s of %s is not of type %T", path, config.ProtoReflect().Descriptor().FullName(), field)
+	}
+	unsubscribe := store.Subscribe(func(old, new Config) {
+		event := Event[Config]{Old: old, New: new, Changed: Diff(old, new)}
+		if !event.HasChanged(path) {
+			return
+		}
+		fn(fieldValue[Field](old.ProtoReflect(), fields), fieldValue[Field](new.ProtoReflect(), fields))
+	})
+	return unsubscribe, nil
+}
+
+// resolvePath returns the fields named by the segments of path, starting from desc.
+func resolvePath(desc protoreflect.MessageDescriptor, path string) ([]protoreflect.FieldDescriptor, error) {
+	var fields []protoreflect.FieldDescriptor
+	for i, name := range strings.Split(path, ".") {
+		if i > 0 {
+			parent := fields[i-1]
+			if parent.Message() == nil || parent.IsList() || parent.IsMap() {
+				return nil, fmt.Errorf("config: field %s is not a message, in path %s", parent.FullName(), path)
+			}
+			desc = parent.Message()
+		}
+		fd := desc.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, fmt.Errorf("config: field %s not found in %s, in path %s", name, desc.FullName(), path)
+		}
+		fields = append(fields, fd)
+	}
+	leaf := fields[len(fields)-1]
+	if leaf.IsList() || leaf.IsMap() {
+		return nil, fmt.Errorf("config: field %s is repeated, in path %s", leaf.FullName(), path)
+	}
+	return fields, nil
+}
+
+// fieldValue returns the value of the field at the end of fields in m,
+// the zero value of Field if a message on the way is unset.
+func fieldValue[Field any](m protoreflect.Message, fields []protoreflect.FieldDescriptor) Field {
+	var zero Field
+	for _, fd := range fields[:len(fields)-1] {
+		if !m.Has(fd) {
+			return zero
+		}
+		m = m.Get(fd).Message()
+	}
+	leaf := fields[len(fields)-1]
+	if leaf.Message() != nil && !m.Has(leaf) {
+		return zero
+	}
+	value, _ := goValue(leaf, m.Get(leaf)).(Field)
+	return value
+}
+
+// zeroFieldValue returns the Go value of an unset fd, used to check its type.
+func zeroFieldValue(fd protoreflect.FieldDescriptor) any {
+	if fd.Message() != nil {
+		mt, err := protoregistry.GlobalTypes.FindMessageByName(fd.Message().FullName())
+		if err != nil {
+			return nil
+		}
+		return mt.Zero().Interface()
+	}
+	return goValue(fd, fd.Default())
+}
+
+// goValue converts v to the Go type generated for fd.
+func goValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch {
+	case fd.Message() != nil:
+		return v.Message().Interface()
+	case fd.Enum() != nil:
+		et, err := protoregistry.GlobalTypes.FindEnumByName(fd.Enum().FullName())
+		if err != nil {
+			return v.Enum()
+		}
+		return et.New(v.Enum())
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-leo/config/test"
+)
+
+func TestOnChange(t *testing.T) {
+	t.Run("Message", func(t *testing.T) {
+		store := NewStore(&test.Gateway{})
+		var calls [][2]*test.Upstream
+		unsubscribe, err := OnChange(store, "fallback", func(old, new *test.Upstream) {
+			calls = append(calls, [2]*test.Upstream{old, new})
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer unsubscribe()
+
+		store.Set(&test.Gateway{Tags: []string{"a"}})
+		if len(calls) != 0 {
+			t.Errorf("Expected no call for unrelated change, got %v", calls)
+		}
+		first := &test.Upstream{Addr: "10.0.0.1"}
+		store.Set(&test.Gateway{Tags: []string{"a"}, Fallback: first})
+		second := &test.Upstream{Addr: "10.0.0.2"}
+		store.Set(&test.Gateway{Fallback: second})
+		expected := [][2]*test.Upstream{{nil, first}, {first, second}}
+		if !reflect.DeepEqual(expected, calls) {
+			t.Errorf("Expected %v, got %v", expected, calls)
+		}
+	})
+
+	t.Run("Scalar", func(t *testing.T) {
+		store := NewStore(&test.Gateway{Fallback: &test.Upstream{Addr: "10.0.0.1"}})
+		var calls [][2]string
+		unsubscribe, err := OnChange(store, "fallback.addr", func(old, new string) {
+			calls = append(calls, [2]string{old, new})
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		store.Set(&test.Gateway{Fallback: &test.Upstream{Addr: "10.0.0.1", Weight: 2}})
+		store.Set(&test.Gateway{Fallback: &test.Upstream{Addr: "10.0.0.2"}})
+		store.Set(&test.Gateway{})
+		unsubscribe()
+		store.Set(&test.Gateway{Fallback: &test.Upstream{Addr: "10.0.0.3"}})
+		expected := [][2]string{{"10.0.0.1", "10.0.0.2"}, {"10.0.0.2", ""}}
+		if !reflect.DeepEqual(expected, calls) {
+			t.Errorf("Expected %v, got %v", expected, calls)
+		}
+	})
+
+	t.Run("InvalidPath", func(t *testing.T) {
+		store := NewStore(&test.Gateway{})
+		tests := []struct {
+			name string
+			path string
+		}{
+			{name: "Unknown", path: "fallbak"},
+			{name: "UnknownNested", path: "fallback.address"},
+			{name: "NotMessage", path: "fallback.addr.host"},
+			{name: "Repeated", path: "tags"},
+			{name: "WrongType", path: "fallback.weight"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if _, err := OnChange(store, tt.path, func(old, new string) {}); err == nil {
+					t.Errorf("Expected error for path %s, got nil", tt.path)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"os"
+	"time"
+
+	"github.com/go-leo/config/encrypt"
+	"github.com/go-leo/config/merge"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Option configures the behaviour of Load and Watch.
+type Option func(o *options)
+
+// options holds the settings collected from Option values.
+type options struct {
+	// merger combines the values loaded from resources, nil means merge.GetMerger()
+	merger merge.Merger
+	// unmarshalOptions used to convert the merged value into the target message
+	unmarshalOptions protojson.UnmarshalOptions
+	// debounce is the quiet period Watch waits after the last change before reloading
+	debounce time.Duration
+	// maxDelay bounds how long a reload can be postponed by a burst of changes, 0 means unbounded
+	maxDelay time.Duration
+	// minInterval is the minimum time between two reloads
+	minInterval time.Duration
+	// clock used to schedule reloads
+	clock clock
+	// errorHandler receives errors raised while watching, nil means send to errC
+	errorHandler func(error)
+	// initial is the configuration Watch compares the first reload with, nil if none
+	initial proto.Message
+	// layers are the values already loaded from the resources of Watch, nil if none
+	layers []merge.Layer
+	// provenanceHandler receives the provenance of every merged configuration
+	provenanceHandler func(merge.Provenance)
+	// validators check the loaded configuration
+	validators []func(proto.Message) error
+	// interpolation enables expanding ${...} references in values
+	interpolation bool
+	// lookupEnv looks up environment variables referenced in values
+	lookupEnv func(key string) (string, bool)
+	// decrypter decrypts the ENC[...] values
+	decrypter encrypt.Decrypter
+}
+
+// apply applies the given options on top of the defaults.
+func (o *options) apply(opts ...Option) *options {
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// getMerger returns the configured merger, falling back to the global one.
+func (o *options) getMerger() merge.Merger {
+	if o.merger != nil {
+		return o.merger
+	}
+	return merge.GetMerger()
+}
+
+// newOptions creates options with default values and applies opts.
+func newOptions(opts ...Option) *options {
+	o := &options{
+		debounce:  time.Second,
+		maxDelay:  5 * time.Second,
+		clock:     realClock{},
+		lookupEnv: os.LookupEnv,
+	}
+	return o.apply(opts...)
+}
+
+// WithMerger sets the merger used to combine resources, instead of the
+// global merger registered with merge.SetMerger.
+func WithMerger(merger merge.Merger) Option {
+	return func(o *options) {
+		o.merger = merger
+	}
+}
+
+// WithUnmarshalOptions sets the protojson options used to convert the merged
+// value into the target message, e.g. DiscardUnknown.
+func WithUnmarshalOptions(unmarshalOptions protojson.UnmarshalOptions) Option {
+	return func(o *options) {
+		o.unmarshalOptions = unmarshalOptions
+	}
+}
+
+// WithDebounce sets the quiet period Watch waits after the last change
+// notification before reloading the configuration, so that a burst of changes
+// results in a single reload. Default is one second.
+func WithDebounce(debounce time.Duration) Option {
+	return func(o *options) {
+		o.debounce = debounce
+	}
+}
+
+// WithMaxDelay bounds how long a continuous burst of changes can postpone a
+// reload, counted from the first change of the burst. Zero means unbounded.
+// Default is five seconds.
+func WithMaxDelay(maxDelay time.Duration) Option {
+	return func(o *options) {
+		o.maxDelay = maxDelay
+	}
+}
+
+// WithMinInterval sets the minimum time between two reloads in Watch.
+// Default is zero, reloads are only limited by the debounce period.
+func WithMinInterval(minInterval time.Duration) Option {
+	return func(o *options) {
+		o.minInterval = minInterval
+	}
+}
+
+// withClock sets the clock used by Watch to schedule reloads, for tests.
+func withClock(c clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// WithErrorHandler sets a function that receives every error raised while
+// watching. When set, errors are no longer sent to the errC channel of Watch.
+func WithErrorHandler(handler func(error)) Option {
+	return func(o *options) {
+		o.errorHandler = handler
+	}
+}
+
+// WithInitial sets the configuration in effect when Watch starts, usually the
+// result of Load. The first update of Watch then carries it as Event.Old and
+// only lists the fields changed since, and a reload that changes nothing is
+// not sent.
+func WithInitial[Config proto.Message](conf Config) Option {
+	return func(o *options) {
+		o.initial = conf
+	}
+}
+
+// withLayers starts Watch from the values already loaded from its resources,
+// in the same order, so that a reload only loads the resources that have not
+// delivered a value yet.
+func withLayers(layers []merge.Layer) Option {
+	return func(o *options) {
+		o.layers = layers
+	}
+}
+
+// WithProvenance asks Load to record which resource supplied each effective
+// value, and passes the result to handler after every successful merge,
+// including reloads in Watch. The merger must implement merge.Tracer.
+func WithProvenance(handler func(merge.Provenance)) Option {
+	return func(o *options) {
+		o.provenanceHandler = handler
+	}
+}
+
+// WithInterpolation enables or disables expanding ${...} references in the
+// merged values, e.g. ${grpc.addr} or ${REDIS_HOST:localhost}. Default is disabled,
+// values containing "${" are kept as they are.
+func WithInterpolation(enabled bool) Option {
+	return func(o *options) {
+		o.interpolation = enabled
+	}
+}
+
+// WithLookupEnv sets the function used to look up the environment variables
+// referenced in values. Default is os.LookupEnv.
+func WithLookupEnv(lookupEnv func(key string) (string, bool)) Option {
+	return func(o *options) {
+		o.lookupEnv = lookupEnv
+	}
+}
+
+// WithDecrypter sets the decrypter of the encrypted values, ENC[...], e.g. an
+// aesgcm.Cipher. Loading fails if a value is encrypted and no decrypter is set.
+func WithDecrypter(decrypter encrypt.Decrypter) Option {
+	return func(o *options) {
+		o.decrypter = decrypter
+	}
+}
@@ -11,6 +11,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
 	reflect "reflect"
+	sync "sync"
 )
 
 const (
@@ -20,6 +21,64 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// MergeStrategy 定义repeated字段在多个资源之间的合并方式
+type MergeStrategy int32
+
+const (
+	// 未设置，等同于REPLACE
+	MergeStrategy_MERGE_STRATEGY_UNSPECIFIED MergeStrategy = 0
+	// 后面资源的列表整体替换前面资源的列表
+	MergeStrategy_REPLACE MergeStrategy = 1
+	// 后面资源的列表元素追加到前面资源的列表之后
+	MergeStrategy_APPEND MergeStrategy = 2
+	// 按merge_key指定的字段匹配列表元素，匹配到的元素递归合并，未匹配到的元素追加到列表之后
+	// 仅对message类型的repeated字段有效
+	MergeStrategy_MERGE_BY_KEY MergeStrategy = 3
+)
+
+// Enum value maps for MergeStrategy.
+var (
+	MergeStrategy_name = map[int32]string{
+		0: "MERGE_STRATEGY_UNSPECIFIED",
+		1: "REPLACE",
+		2: "APPEND",
+		3: "MERGE_BY_KEY",
+	}
+	MergeStrategy_value = map[string]int32{
+		"MERGE_STRATEGY_UNSPECIFIED": 0,
+		"REPLACE":                    1,
+		"APPEND":                     2,
+		"MERGE_BY_KEY":               3,
+	}
+)
+
+func (x MergeStrategy) Enum() *MergeStrategy {
+	p := new(MergeStrategy)
+	*p = x
+	return p
+}
+
+func (x MergeStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MergeStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_annotations_proto_enumTypes[0].Descriptor()
+}
+
+func (MergeStrategy) Type() protoreflect.EnumType {
+	return &file_annotations_proto_enumTypes[0]
+}
+
+func (x MergeStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MergeStrategy.Descriptor instead.
+func (MergeStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_annotations_proto_rawDescGZIP(), []int{0}
+}
+
 var file_annotations_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
@@ -29,6 +88,46 @@ var file_annotations_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "varint,70501,opt,name=enable",
 		Filename:      "annotations.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*MergeStrategy)(nil),
+		Field:         70502,
+		Name:          "leo.config.merge",
+		Tag:           "varint,70502,opt,name=merge,enum=leo.config.MergeStrategy",
+		Filename:      "annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         70503,
+		Name:          "leo.config.merge_key",
+		Tag:           "bytes,70503,opt,name=merge_key",
+		Filename:      "annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         70504,
+		Name:          "leo.config.default",
+		Tag:           "bytes,70504,opt,name=default",
+		Filename:      "annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         70505,
+		Name:          "leo.config.required",
+		Tag:           "varint,70505,opt,name=required",
+		Filename:      "annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         70506,
+		Name:          "leo.config.sensitive",
+		Tag:           "varint,70506,opt,name=sensitive",
+		Filename:      "annotations.proto",
+	},
 }
 
 // Extension fields to descriptorpb.MessageOptions.
@@ -40,6 +139,34 @@ var (
 	E_Enable = &file_annotations_proto_extTypes[0]
 )
 
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// repeated字段的合并策略
+	//
+	// optional leo.config.MergeStrategy merge = 70502;
+	E_Merge = &file_annotations_proto_extTypes[1]
+	// merge为MERGE_BY_KEY时，用于匹配列表元素的字段名
+	//
+	// optional string merge_key = 70503;
+	E_MergeKey = &file_annotations_proto_extTypes[2]
+	// 字段的默认值，优先级低于所有资源
+	// 写法为字段的JSON形式，例如8080、true、["a","b"]
+	// string字段以及不是合法JSON的值，直接作为字符串，例如127.0.0.1、1s
+	//
+	// optional string default = 70504;
+	E_Default = &file_annotations_proto_extTypes[3]
+	// 字段是否必填，合并后没有任何资源设置该字段时，加载失败
+	// 嵌套message中的必填字段只在该message已设置时检查，需要时把message字段也标记为必填
+	// 声明了默认值的字段总是已设置的
+	//
+	// optional bool required = 70505;
+	E_Required = &file_annotations_proto_extTypes[4]
+	// 字段是否敏感，例如密码，打印、导出、比较配置时会被隐藏
+	//
+	// optional bool sensitive = 70506;
+	E_Sensitive = &file_annotations_proto_extTypes[5]
+)
+
 var File_annotations_proto protoreflect.FileDescriptor
 
 var file_annotations_proto_rawDesc = []byte{
@@ -47,25 +174,72 @@ var file_annotations_proto_rawDesc = []byte{
 	0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x6c, 0x65, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a,
 	0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
 	0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x3a, 0x39, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe5, 0xa6, 0x04,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x32, 0x5a, 0x30,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6c, 0x65,
-	0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6c,
-	0x65, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x3b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x2a, 0x5a, 0x0a, 0x0d, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x12, 0x1e, 0x0a, 0x1a, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x41,
+	0x54, 0x45, 0x47, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x50, 0x4c, 0x41, 0x43, 0x45, 0x10, 0x01, 0x12,
+	0x0a, 0x0a, 0x06, 0x41, 0x50, 0x50, 0x45, 0x4e, 0x44, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x4d,
+	0x45, 0x52, 0x47, 0x45, 0x5f, 0x42, 0x59, 0x5f, 0x4b, 0x45, 0x59, 0x10, 0x03, 0x3a, 0x39, 0x0a,
+	0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe5, 0xa6, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x3a, 0x50, 0x0a, 0x05, 0x6d, 0x65, 0x72, 0x67,
+	0x65, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0xe6, 0xa6, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6c, 0x65, 0x6f, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x67, 0x79, 0x52, 0x05, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x3a, 0x3c, 0x0a, 0x09, 0x6d, 0x65,
+	0x72, 0x67, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe7, 0xa6, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6d, 0x65, 0x72, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x3a, 0x39, 0x0a, 0x07, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0xe8, 0xa6, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x3a, 0x3b, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12,
+	0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe9,
+	0xa6, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x3a, 0x3d, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x1d, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xea, 0xa6, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x42,
+	0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f,
+	0x2d, 0x6c, 0x65, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x6c, 0x65, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x3b, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_annotations_proto_rawDescOnce sync.Once
+	file_annotations_proto_rawDescData = file_annotations_proto_rawDesc
+)
+
+func file_annotations_proto_rawDescGZIP() []byte {
+	file_annotations_proto_rawDescOnce.Do(func() {
+		file_annotations_proto_rawDescData = protoimpl.X.CompressGZIP(file_annotations_proto_rawDescData)
+	})
+	return file_annotations_proto_rawDescData
 }
 
+var file_annotations_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_annotations_proto_goTypes = []any{
-	(*descriptorpb.MessageOptions)(nil), // 0: google.protobuf.MessageOptions
+	(MergeStrategy)(0),                  // 0: leo.config.MergeStrategy
+	(*descriptorpb.MessageOptions)(nil), // 1: google.protobuf.MessageOptions
+	(*descriptorpb.FieldOptions)(nil),   // 2: google.protobuf.FieldOptions
 }
 var file_annotations_proto_depIdxs = []int32{
-	0, // 0: leo.config.enable:extendee -> google.protobuf.MessageOptions
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	0, // [0:1] is the sub-list for extension extendee
+	1, // 0: leo.config.enable:extendee -> google.protobuf.MessageOptions
+	2, // 1: leo.config.merge:extendee -> google.protobuf.FieldOptions
+	2, // 2: leo.config.merge_key:extendee -> google.protobuf.FieldOptions
+	2, // 3: leo.config.default:extendee -> google.protobuf.FieldOptions
+	2, // 4: leo.config.required:extendee -> google.protobuf.FieldOptions
+	2, // 5: leo.config.sensitive:extendee -> google.protobuf.FieldOptions
+	0, // 6: leo.config.merge:type_name -> leo.config.MergeStrategy
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	6, // [6:7] is the sub-list for extension type_name
+	0, // [0:6] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
 }
 
@@ -79,13 +253,14 @@ func file_annotations_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_annotations_proto_rawDesc,
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   0,
-			NumExtensions: 1,
+			NumExtensions: 6,
 			NumServices:   0,
 		},
 		GoTypes:           file_annotations_proto_goTypes,
 		DependencyIndexes: file_annotations_proto_depIdxs,
+		EnumInfos:         file_annotations_proto_enumTypes,
 		ExtensionInfos:    file_annotations_proto_extTypes,
 	}.Build()
 	File_annotations_proto = out.File
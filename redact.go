@@ -0,0 +1,84 @@
+package config
+
+import (
+	"github.com/go-leo/config/proto/leo/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Mask replaces the value of sensitive string fields in redacted messages.
+const Mask = "******"
+
+// Redact returns a copy of msg where every field marked with
+// (leo.config.sensitive), in msg and its nested messages, is masked:
+// strings and bytes are replaced by Mask, other fields are cleared.
+// Unset fields stay unset, so the output still shows which secrets are missing.
+func Redact[M proto.Message](msg M) M {
+	if !msg.ProtoReflect().IsValid() {
+		return msg
+	}
+	redacted := proto.Clone(msg).(M)
+	redactMessage(redacted.ProtoReflect())
+	return redacted
+}
+
+// redactMessage masks the sensitive fields of m in place.
+func redactMessage(m protoreflect.Message) {
+	var sensitive []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if proto.GetExtension(fd.Options(), config.E_Sensitive).(bool) {
+			sensitive = append(sensitive, fd)
+			return true
+		}
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, item protoreflect.Value) bool {
+					redactMessage(item.Message())
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				for i := 0; i < v.List().Len(); i++ {
+					redactMessage(v.List().Get(i).Message())
+				}
+			}
+		case fd.Message() != nil:
+			redactMessage(v.Message())
+		}
+		return true
+	})
+	for _, fd := range sensitive {
+		redactField(m, fd)
+	}
+}
+
+// redactField masks the value of the sensitive field fd of m.
+func redactField(m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	if fd.IsList() || fd.IsMap() {
+		if fd.IsList() && (fd.Kind() == protoreflect.StringKind || fd.Kind() == protoreflect.BytesKind) {
+			list := m.Mutable(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				list.Set(i, maskValue(fd))
+			}
+			return
+		}
+		m.Clear(fd)
+		return
+	}
+	switch fd.Kind() {
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		m.Set(fd, maskValue(fd))
+	default:
+		m.Clear(fd)
+	}
+}
+
+// maskValue returns Mask as a value of the string or bytes field fd.
+func maskValue(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	if fd.Kind() == protoreflect.BytesKind {
+		return protoreflect.ValueOfBytes([]byte(Mask))
+	}
+	return protoreflect.ValueOfString(Mask)
+}
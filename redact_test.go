@@ -0,0 +1,56 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-leo/config/test"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRedact(t *testing.T) {
+	credentials := &test.Credentials{
+		User:     "admin",
+		Password: "s3cr3t",
+		Pin:      1234,
+		Tokens:   []string{"t1", "t2"},
+		Accounts: map[string]*test.Credentials{"backup": {User: "backup", Password: "b4ckup"}},
+		Backups:  []*test.Credentials{{Password: "old"}},
+		Parent:   &test.Credentials{User: "root"},
+		Upstream: &test.Upstream{Addr: "10.0.0.1"},
+	}
+	original := proto.Clone(credentials)
+	expected := &test.Credentials{
+		User:     "admin",
+		Password: Mask,
+		Tokens:   []string{Mask, Mask},
+		Accounts: map[string]*test.Credentials{"backup": {User: "backup", Password: Mask}},
+		Backups:  []*test.Credentials{{Password: Mask}},
+		Parent:   &test.Credentials{User: "root"},
+	}
+	redacted := Redact(credentials)
+	if !proto.Equal(expected, redacted) {
+		t.Errorf("Expected %v, got %v", expected, redacted)
+	}
+	if !proto.Equal(original, credentials) {
+		t.Errorf("Expected input to be unchanged, got %v", credentials)
+	}
+
+	var empty *test.Credentials
+	if Redact(empty) != nil {
+		t.Error("Expected nil message to stay nil")
+	}
+}
+
+func TestEventStringRedacted(t *testing.T) {
+	old := &test.Credentials{User: "admin", Password: "old-s3cr3t"}
+	new := &test.Credentials{User: "admin", Password: "new-s3cr3t"}
+	event := Event[*test.Credentials]{Old: old, New: new, Changed: Diff(old, new)}
+	s := event.String()
+	if strings.Contains(s, "s3cr3t") {
+		t.Errorf("Expected secrets to be redacted, got %s", s)
+	}
+	if !strings.Contains(s, "password") || !strings.Contains(s, Mask) {
+		t.Errorf("Expected changed password to be reported masked, got %s", s)
+	}
+}
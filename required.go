@@ -0,0 +1,97 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-leo/config/merge"
+	"github.com/go-leo/config/proto/leo/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// MissingFieldsError is returned by Load and reported by Watch when fields
+// marked with (leo.config.required) are not set by any resource.
+type MissingFieldsError struct {
+	// Paths of the missing fields, e.g. redis.addr or upstreams[0].name
+	Paths []string
+}
+
+func (e *MissingFieldsError) Error() string {
+	return "config: missing required fields: " + strings.Join(e.Paths, ", ")
+}
+
+// checkRequired returns a MissingFieldsError listing every required field of
+// desc that is not set in the merged value. present is the value merged from
+// the resources without the defaults, it decides whether messages are set.
+func checkRequired(desc protoreflect.MessageDescriptor, value *structpb.Struct, present *structpb.Struct) error {
+	var paths []string
+	missingFields(desc, value, present, "", &paths)
+	if len(paths) == 0 {
+		return nil
+	}
+	sort.Strings(paths)
+	return &MissingFieldsError{Paths: paths}
+}
+
+// missingFields appends the paths of the required fields of desc missing in
+// value to paths. The fields of nested messages are only checked when the
+// message is set in present, mark the message field itself as required to
+// enforce it. Fields declaring a default value are always set.
+func missingFields(desc protoreflect.MessageDescriptor, value *structpb.Struct, present *structpb.Struct, path string, paths *[]string) {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		fieldPath := string(fd.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		field, ok := lookupField(value, fd)
+		presentField, isPresent := lookupField(present, fd)
+		if fd.Message() != nil {
+			// messages only created by the defaults are not set
+			ok = ok && isPresent
+		}
+		if !ok && proto.GetExtension(fd.Options(), config.E_Required).(bool) && !proto.HasExtension(fd.Options(), config.E_Default) {
+			*paths = append(*paths, fieldPath)
+			continue
+		}
+		if !ok || fd.Message() == nil {
+			continue
+		}
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				continue
+			}
+			for key, item := range field.GetStructValue().GetFields() {
+				presentItem := presentField.GetStructValue().GetFields()[key]
+				missingFields(fd.MapValue().Message(), item.GetStructValue(), presentItem.GetStructValue(), fieldPath+"."+key, paths)
+			}
+		case fd.IsList():
+			presentItems := presentField.GetListValue().GetValues()
+			for index, item := range field.GetListValue().GetValues() {
+				var presentItem *structpb.Value
+				if index < len(presentItems) {
+					presentItem = presentItems[index]
+				}
+				missingFields(fd.Message(), item.GetStructValue(), presentItem.GetStructValue(), fieldPath+"["+strconv.Itoa(index)+"]", paths)
+			}
+		default:
+			missingFields(fd.Message(), field.GetStructValue(), presentField.GetStructValue(), fieldPath, paths)
+		}
+	}
+}
+
+// lookupField returns the value of fd in value, by proto name or JSON name.
+// Explicit nulls are not set.
+func lookupField(value *structpb.Struct, fd protoreflect.FieldDescriptor) (*structpb.Value, bool) {
+	for _, key := range []string{string(fd.Name()), fd.JSONName()} {
+		if field, ok := value.GetFields()[key]; ok && !merge.IsNull(field) {
+			return field, true
+		}
+	}
+	return nil, false
+}
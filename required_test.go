@@ -0,0 +1,112 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-leo/config/resource"
+	"github.com/go-leo/config/test"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestLoadRequired(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   map[string]interface{}
+		missing []string
+	}{
+		{
+			name: "AllSet",
+			// port of primary is set by its default value, backup is not set
+			value: map[string]interface{}{"name": "a", "primary": map[string]interface{}{"addr": "10.0.0.1"}},
+		},
+		{
+			// primary is only created by the default value of its port
+			name:    "Empty",
+			value:   map[string]interface{}{},
+			missing: []string{"name", "primary"},
+		},
+		{
+			name:    "OptionalMessageSet",
+			value:   map[string]interface{}{"name": "a", "primary": map[string]interface{}{"addr": "10.0.0.1"}, "backup": map[string]interface{}{}},
+			missing: []string{"backup.addr"},
+		},
+		{
+			name:    "NestedAndNull",
+			value:   map[string]interface{}{"name": nil, "primary": map[string]interface{}{"port": 8080}},
+			missing: []string{"name", "primary.addr"},
+		},
+		{
+			name: "ListsMapsAndRecursion",
+			value: map[string]interface{}{
+				"name":     "a",
+				"primary":  map[string]interface{}{"addr": "10.0.0.1"},
+				"replicas": []interface{}{map[string]interface{}{"addr": "10.0.0.2"}, map[string]interface{}{"port": 8080}},
+				"zones":    map[string]interface{}{"eu": map[string]interface{}{}},
+				"parent":   map[string]interface{}{},
+			},
+			missing: []string{"parent.name", "parent.primary", "replicas[1].addr", "zones.eu.addr"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, _ := structpb.NewStruct(tt.value)
+			_, err := Load[*test.Cluster](context.Background(), []resource.Resource{&mockLoadResource{value: value}})
+			if tt.missing == nil {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return
+			}
+			var missingErr *MissingFieldsError
+			if !errors.As(err, &missingErr) {
+				t.Fatalf("Expected missing fields error, got %v", err)
+			}
+			if !reflect.DeepEqual(tt.missing, missingErr.Paths) {
+				t.Errorf("Expected missing %v, got %v", tt.missing, missingErr.Paths)
+			}
+		})
+	}
+}
+
+func TestWatchRequired(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notifyC := make(chan Event[*test.Cluster], 1)
+	errC := make(chan error, 1)
+	changeC := make(chan *structpb.Struct)
+	res := &mockResource{
+		watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+			go func() {
+				for v := range changeC {
+					notifyC <- v
+				}
+			}()
+			return func(ctx context.Context) error { return nil }, nil
+		},
+	}
+	watcher, err := Watch[*test.Cluster](ctx, notifyC, errC, []resource.Resource{res}, WithDebounce(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer watcher.Close(ctx)
+	defer close(changeC)
+
+	v, _ := structpb.NewStruct(map[string]interface{}{"name": "a"})
+	changeC <- v
+	select {
+	case err := <-errC:
+		var missingErr *MissingFieldsError
+		if !errors.As(err, &missingErr) || !reflect.DeepEqual([]string{"primary"}, missingErr.Paths) {
+			t.Errorf("Expected primary to be missing, got %v", err)
+		}
+	case event := <-notifyC:
+		t.Fatalf("Unexpected notification %v", event)
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for missing fields error")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/go-leo/config/format"
@@ -49,6 +50,11 @@ func (r *Resource) load(ctx context.Context) ([]byte, error) {
 	return pair.Value, nil
 }
 
+// String returns the name of the resource, e.g. consul:app/config.yaml
+func (r *Resource) String() string {
+	return "consul:" + r.key
+}
+
 // Watch sets up a watcher for configuration changes in Consul
 // notifyC: channel to receive new configuration when changed
 // errC: channel to receive errors during watching
@@ -65,6 +71,8 @@ func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, e
 	if err != nil {
 		return nil, err
 	}
+	stopC := make(chan struct{})
+	doneC := make(chan struct{})
 	plan.Handler = func(idx uint64, raw interface{}) {
 		if raw == nil {
 			return
@@ -80,31 +88,43 @@ func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, e
 		}
 		newValue, err := r.formatter.Parse(data)
 		if err != nil {
-			errC <- err
+			send(ctx, stopC, errC, err)
+			return
+		}
+		if !send(ctx, stopC, notifyC, newValue) {
 			return
 		}
-		notifyC <- newValue
 		r.data.Store(data)
 	}
 	go func() {
+		defer close(doneC)
 		_ = plan.RunWithClientAndHclog(
 			r.client,
 			&consuleLogger{
 				Logger: hclog.NewNullLogger(),
+				ctx:    ctx,
+				stopC:  stopC,
 				errC:   errC,
 			})
 	}()
-	stopC := make(chan struct{})
 	go func() {
 		select {
 		case <-ctx.Done():
 		case <-stopC:
+		case <-doneC:
 		}
 		plan.Stop()
 	}()
+	var stopOnce sync.Once
+	// stop terminates the plan and waits for it to exit
 	stop := func(ctx context.Context) error {
-		close(stopC)
-		return nil
+		stopOnce.Do(func() { close(stopC) })
+		select {
+		case <-doneC:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 	return stop, nil
 }
@@ -112,12 +132,27 @@ func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, e
 // consuleLogger is a custom logger that forwards errors to error channel
 type consuleLogger struct {
 	hclog.Logger
-	errC chan<- error // Channel to forward errors
+	ctx   context.Context // Context of the watch
+	stopC <-chan struct{} // Closed when the watch is stopped
+	errC  chan<- error    // Channel to forward errors
 }
 
 // Error implements the hclog.Logger interface and forwards errors to errC
 func (l *consuleLogger) Error(msg string, args ...interface{}) {
-	l.errC <- fmt.Errorf(msg, args...)
+	send(l.ctx, l.stopC, l.errC, fmt.Errorf(msg, args...))
+}
+
+// send delivers v to c unless ctx is done or stopC is closed first.
+// Returns false if v was not delivered.
+func send[T any](ctx context.Context, stopC <-chan struct{}, c chan<- T, v T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-stopC:
+		return false
+	case c <- v:
+		return true
+	}
 }
 
 // New creates a new Consul configuration resource
@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"golang.org/x/exp/slices"
 
 	"github.com/go-leo/config/format"
+	"github.com/go-leo/config/resource"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -23,6 +25,23 @@ type Resource struct {
 	formatter format.Formatter
 	// Atomic storage for the configuration data
 	data atomic.Value
+	// separator splits variable names into nested keys
+	separator string
+}
+
+// DefaultSeparator is the default separator of nested keys,
+// e.g. APP_REDIS__ADDR sets redis.addr.
+const DefaultSeparator = "__"
+
+// Option configures an env Resource
+type Option func(r *Resource)
+
+// WithSeparator sets the separator splitting variable names into nested keys.
+// Default is DefaultSeparator.
+func WithSeparator(separator string) Option {
+	return func(r *Resource) {
+		r.separator = separator
+	}
 }
 
 // Load retrieves and parses environment variables with the specified prefix
@@ -32,7 +51,61 @@ func (r *Resource) Load(ctx context.Context) (*structpb.Struct, error) {
 		return nil, err
 	}
 	r.data.Store(data)
-	return r.formatter.Parse(data)
+	return r.parse(data)
+}
+
+// parse parses the variables and nests their values by key.
+// The prefix is removed from every name, the rest is lowercased and split
+// on the separator, e.g. with the prefix APP_, APP_REDIS__ADDR sets redis.addr.
+// Names such as APP_REDIS_DB or APP_SERVERS_0_HOST are matched to nested fields
+// and list items when loaded into a message.
+// Variables are applied in name order and the last one wins, so when a name is
+// both a value and the parent of nested keys, e.g. APP_REDIS and APP_REDIS__ADDR,
+// the nested keys win and APP_REDIS is ignored.
+func (r *Resource) parse(data []byte) (*structpb.Struct, error) {
+	flat, err := r.formatter.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(flat.GetFields()))
+	for name := range flat.GetFields() {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	value := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimPrefix(name, r.prefix))
+		keys := []string{key}
+		if r.separator != "" {
+			keys = strings.Split(key, r.separator)
+		}
+		if err := setNested(value, keys, flat.GetFields()[name]); err != nil {
+			return nil, fmt.Errorf("config: environment variable %s: %w", name, err)
+		}
+	}
+	return value, nil
+}
+
+// setNested sets the value at the path of keys, creating nested structs.
+// A value replaces the nested keys set before at its path, and nested keys
+// replace a value set before at the path of their parent.
+func setNested(s *structpb.Struct, keys []string, value *structpb.Value) error {
+	for i, key := range keys {
+		if key == "" {
+			return fmt.Errorf("empty key")
+		}
+		if i == len(keys)-1 {
+			s.Fields[key] = value
+			return nil
+		}
+		nested := s.GetFields()[key]
+		if nested.GetStructValue() == nil {
+			nested = structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{}})
+			s.Fields[key] = nested
+		}
+		s = nested.GetStructValue()
+	}
+	return nil
 }
 
 // load collects and prepares environment variables data
@@ -41,7 +114,8 @@ func (r *Resource) load(ctx context.Context) ([]byte, error) {
 	// Filter environment variables by prefix
 	for _, environ := range os.Environ() {
 		if strings.HasPrefix(environ, r.prefix) {
-			environs = append(environs, []byte(environ))
+			name, value, _ := strings.Cut(environ, "=")
+			environs = append(environs, []byte(name+"="+quote(value)))
 		}
 	}
 	if len(environs) <= 0 {
@@ -52,6 +126,26 @@ func (r *Resource) load(ctx context.Context) ([]byte, error) {
 	return bytes.Join(environs, []byte("\n")), nil
 }
 
+// quote double-quotes value for the env formatter, so that quotes,
+// comments and newlines in the value are kept.
+func quote(value string) string {
+	return `"` + quoteReplacer.Replace(value) + `"`
+}
+
+var quoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+
+// Loose reports that environment variables are loosely typed, their names
+// are matched to fields ignoring case and their values converted to the
+// types of the fields.
+func (r *Resource) Loose() bool {
+	return true
+}
+
+// String returns the name of the resource, e.g. env:APP_
+func (r *Resource) String() string {
+	return "env:" + r.prefix
+}
+
 // Watch monitors environment variables for changes
 // notifyC: channel to receive new configuration when variables change
 // errC: channel to receive errors during watching
@@ -61,12 +155,21 @@ func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, e
 		return nil, ctx.Err()
 	}
 	stopC := make(chan struct{})
+	doneC := make(chan struct{})
+	var stopOnce sync.Once
+	// stop terminates the watching goroutine and waits for it to exit
 	stop := func(ctx context.Context) error {
-		close(stopC)
-		return nil
+		stopOnce.Do(func() { close(stopC) })
+		select {
+		case <-doneC:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 	// Start watching in a separate goroutine
 	go func() {
+		defer close(doneC)
 		for {
 			select {
 			case <-ctx.Done():
@@ -77,19 +180,21 @@ func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, e
 				// Check for changes every second
 				data, err := r.load(ctx)
 				if err != nil {
-					errC <- err
+					resource.Send(ctx, stopC, errC, err)
 					continue
 				}
 				preData := r.data.Load()
 				if preData != nil && bytes.Equal(preData.([]byte), data) {
 					continue // Skip if no changes
 				}
-				newValue, err := r.formatter.Parse(data)
+				newValue, err := r.parse(data)
 				if err != nil {
-					errC <- err
+					resource.Send(ctx, stopC, errC, err)
 					continue
 				}
-				notifyC <- newValue
+				if !resource.Send(ctx, stopC, notifyC, newValue) {
+					return
+				}
 				r.data.Store(data)
 			}
 		}
@@ -99,15 +204,21 @@ func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, e
 
 // New creates a new environment variable configuration resource
 // prefix: The prefix used to filter environment variables (e.g., "APP_")
+// opts: Options such as WithSeparator
 // Returns the Resource instance or error if initialization fails
-func New(prefix string) (*Resource, error) {
+func New(prefix string, opts ...Option) (*Resource, error) {
 	ext := "env"
 	formatter, ok := format.GetFormatter(ext)
 	if !ok {
 		return nil, fmt.Errorf("config: not found formatter for %s", ext)
 	}
-	return &Resource{
+	r := &Resource{
 		prefix:    prefix,
 		formatter: formatter,
-	}, nil
+		separator: DefaultSeparator,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
 }
@@ -41,7 +41,7 @@ func TestLoad(t *testing.T) {
 		os.Setenv(tc.key, tc.value)
 		defer os.Unsetenv(tc.key)
 		if strings.HasPrefix(tc.key, "TEST_") {
-			expected[tc.key] = tc.value
+			expected[strings.ToLower(strings.TrimPrefix(tc.key, "TEST_"))] = tc.value
 		}
 	}
 
@@ -94,7 +94,7 @@ func TestWatch(t *testing.T) {
 				t.Error("received nil value")
 				return
 			}
-			val := newVal.GetFields()["TEST_KEY"].GetStringValue()
+			val := newVal.GetFields()["key"].GetStringValue()
 			if val != "updated" {
 				t.Errorf("expected value 'updated'; got %q", val)
 			}
@@ -112,3 +112,64 @@ func TestWatch(t *testing.T) {
 	// 确保测试完成
 	wg.Wait()
 }
+
+func TestLoadNested(t *testing.T) {
+	tests := []struct {
+		name     string
+		environ  map[string]string
+		opts     []Option
+		expected map[string]any
+		wantErr  bool
+	}{
+		{
+			name:     "DefaultSeparator",
+			environ:  map[string]string{"NESTED_REDIS__ADDR": "127.0.0.1", "NESTED_REDIS__DB": "3", "NESTED_SERVERS_0_HOST": "a"},
+			expected: map[string]any{"redis": map[string]any{"addr": "127.0.0.1", "db": "3"}, "servers_0_host": "a"},
+		},
+		{
+			name:     "WithSeparator",
+			environ:  map[string]string{"NESTED_SERVERS_0_HOST": "a", "NESTED_DEBUG": "true"},
+			opts:     []Option{WithSeparator("_")},
+			expected: map[string]any{"servers": map[string]any{"0": map[string]any{"host": "a"}}, "debug": "true"},
+		},
+		{
+			name:     "SpecialValues",
+			environ:  map[string]string{"NESTED_QUOTED": `'a' "b" \n`, "NESTED_COMMENT": "a #b", "NESTED_LINES": "a\nb\r\n"},
+			expected: map[string]any{"quoted": `'a' "b" \n`, "comment": "a #b", "lines": "a\nb\r\n"},
+		},
+		{
+			name:     "NestedOverValue",
+			environ:  map[string]string{"NESTED_REDIS": "x", "NESTED_REDIS__ADDR": "127.0.0.1"},
+			expected: map[string]any{"redis": map[string]any{"addr": "127.0.0.1"}},
+		},
+		{
+			name:    "EmptyKey",
+			environ: map[string]string{"NESTED_REDIS____ADDR": "127.0.0.1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.environ {
+				t.Setenv(key, value)
+			}
+			resource, err := New("NESTED_", tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := resource.Load(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(data.AsMap(), tt.expected) {
+				t.Errorf("expected:\n%v\ngot:\n%v", tt.expected, data.AsMap())
+			}
+		})
+	}
+}
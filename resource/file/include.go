@@ -0,0 +1,165 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-leo/config/format"
+	"github.com/go-leo/config/merge"
+	"github.com/go-leo/config/merge/deep"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Include is the reserved top-level key listing the files a configuration file
+// includes, e.g. `$include: [common.yaml, redis.yaml]`. Relative paths are
+// resolved against the directory of the including file. Included files are
+// merged in order, and the keys of the including file take precedence.
+// The merge.Delete tombstones of the including file are kept, so that they
+// also delete the keys set by the resources merged before the file.
+const Include = "$include"
+
+// loadFile reads and parses filename, then merges the files it includes
+// beneath its own keys. stack lists the files being included, to detect
+// cycles, files collects every file read.
+func (r *Resource) loadFile(filename string, formatter format.Formatter, optional bool, stack []string, files *[]string) (*structpb.Struct, error) {
+	filename = filepath.Clean(filename)
+	*files = append(*files, filename)
+	for index, including := range stack {
+		if including == filename {
+			return nil, fmt.Errorf("config: include cycle: %s", strings.Join(append(stack[index:], filename), " -> "))
+		}
+	}
+	stack = append(stack, filename)
+
+	data, err := os.ReadFile(filename)
+	if optional && errors.Is(err, fs.ErrNotExist) {
+		return &structpb.Struct{Fields: map[string]*structpb.Value{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	value, err := formatter.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to parse %s: %w", filename, err)
+	}
+	includes, err := includedFiles(filename, value)
+	if err != nil {
+		return nil, err
+	}
+	if len(includes) == 0 {
+		return value, nil
+	}
+	delete(value.GetFields(), Include)
+
+	values := make([]*structpb.Struct, 0, len(includes)+1)
+	for _, include := range includes {
+		ext := strings.TrimPrefix(filepath.Ext(include), ".")
+		formatter, ok := format.GetFormatter(ext)
+		if !ok {
+			return nil, fmt.Errorf("config: not found formatter for %s, included by %s", ext, filename)
+		}
+		included, err := r.loadFile(include, formatter, false, stack, files)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, included)
+	}
+	values = append(values, value)
+	merged := deep.Merger{}.Merge(values...)
+	keepTombstones(merged, value)
+	return merged, nil
+}
+
+// keepTombstones sets the merge.Delete tombstones of source again in merged,
+// since merging the included files removed them.
+func keepTombstones(merged *structpb.Struct, source *structpb.Struct) {
+	for key, field := range source.GetFields() {
+		if merge.IsDelete(field) {
+			merged.Fields[key] = structpb.NewStringValue(merge.Delete)
+			continue
+		}
+		if nested := field.GetStructValue(); nested != nil {
+			if target := merged.GetFields()[key].GetStructValue(); target != nil {
+				keepTombstones(target, nested)
+			}
+		}
+	}
+}
+
+// includedFiles returns the paths of the files listed under the Include key of
+// value, resolved against the directory of filename.
+func includedFiles(filename string, value *structpb.Struct) ([]string, error) {
+	include, ok := value.GetFields()[Include]
+	if !ok {
+		return nil, nil
+	}
+	var names []string
+	switch kind := include.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		names = append(names, kind.StringValue)
+	case *structpb.Value_ListValue:
+		for _, item := range kind.ListValue.GetValues() {
+			name, ok := item.GetKind().(*structpb.Value_StringValue)
+			if !ok {
+				return nil, fmt.Errorf("config: %s of %s must list file names", Include, filename)
+			}
+			names = append(names, name.StringValue)
+		}
+	default:
+		return nil, fmt.Errorf("config: %s of %s must be a file name or a list of file names", Include, filename)
+	}
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(filepath.Dir(filename), name)
+		}
+		paths = append(paths, name)
+	}
+	return paths, nil
+}
+
+// watchSet tracks the files Watch reacts to, and the directories added to the
+// filesystem watcher for them.
+type watchSet struct {
+	// fsWatcher the filesystem watcher
+	fsWatcher *fsnotify.Watcher
+	// files the cleaned paths of the watched files
+	files map[string]bool
+	// dirs the directories added to fsWatcher
+	dirs map[string]bool
+}
+
+// newWatchSet creates an empty watch set on fsWatcher.
+func newWatchSet(fsWatcher *fsnotify.Watcher) *watchSet {
+	return &watchSet{fsWatcher: fsWatcher, files: map[string]bool{}, dirs: map[string]bool{}}
+}
+
+// update replaces the watched files, adding the directories not watched yet.
+func (s *watchSet) update(files []string) error {
+	s.files = make(map[string]bool, len(files))
+	var errs []error
+	for _, file := range files {
+		file = filepath.Clean(file)
+		s.files[file] = true
+		dir := filepath.Dir(file)
+		if s.dirs[dir] {
+			continue
+		}
+		if err := s.fsWatcher.Add(dir); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		s.dirs[dir] = true
+	}
+	return errors.Join(errs...)
+}
+
+// contains reports whether name is a watched file.
+func (s *watchSet) contains(name string) bool {
+	return s.files[filepath.Clean(name)]
+}
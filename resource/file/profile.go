@@ -0,0 +1,77 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-leo/config/resource"
+	"golang.org/x/exp/slices"
+)
+
+// ProfileEnv is the environment variable holding the active profiles,
+// comma separated, e.g. LEO_RUN_ENV=prod or LEO_RUN_ENV=prod,eu.
+const ProfileEnv = "LEO_RUN_ENV"
+
+// LocalProfile is the profile of the local overrides, always layered last.
+const LocalProfile = "local"
+
+// NewProfiled creates the resources of a configuration file and its profile
+// overlays, ordered from lowest to highest precedence, e.g. for config.yaml
+// and the profile dev:
+//
+//	config.yaml        required
+//	config.dev.yaml    optional
+//	config.local.yaml  optional
+//
+// If no profiles are given, they are read from the ProfileEnv environment variable.
+// Repeated profiles are loaded once, the local profile is always loaded last.
+// Every file is watched, optional files are picked up once created.
+func NewProfiled(filename string, profiles ...string) ([]resource.Resource, error) {
+	if len(profiles) == 0 {
+		profiles = Profiles()
+	}
+	base, err := New(filename)
+	if err != nil {
+		return nil, err
+	}
+	resources := []resource.Resource{base}
+	for _, profile := range layerProfiles(profiles) {
+		overlay, err := New(ProfileFilename(filename, profile), Optional())
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, overlay)
+	}
+	return resources, nil
+}
+
+// layerProfiles returns a copy of profiles without duplicates, ending with the
+// local profile.
+func layerProfiles(profiles []string) []string {
+	layered := make([]string, 0, len(profiles)+1)
+	for _, profile := range slices.Clone(profiles) {
+		if profile != LocalProfile && !slices.Contains(layered, profile) {
+			layered = append(layered, profile)
+		}
+	}
+	return append(layered, LocalProfile)
+}
+
+// Profiles returns the active profiles read from the ProfileEnv environment variable.
+func Profiles() []string {
+	var profiles []string
+	for _, profile := range strings.Split(os.Getenv(ProfileEnv), ",") {
+		if profile = strings.TrimSpace(profile); profile != "" && profile != LocalProfile {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// ProfileFilename returns the name of the overlay of filename for profile,
+// e.g. config.dev.yaml for config.yaml and dev.
+func ProfileFilename(filename string, profile string) string {
+	ext := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, ext) + "." + profile + ext
+}
@@ -1,16 +1,18 @@
 package file
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/go-leo/config/format"
+	"github.com/go-leo/config/resource"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -22,23 +24,72 @@ type Resource struct {
 	ext string
 	// formatter for parsing file content
 	formatter format.Formatter
-	// data atomic storage for configuration data
-	data atomic.Value
+	// value atomic storage for the last loaded configuration
+	value atomic.Pointer[structpb.Struct]
+	// optional reports whether a missing file is an empty configuration
+	optional bool
+	// loose reports whether the values are loosely typed
+	loose bool
 }
 
-// Load reads and parses the configuration file
+// Option configures a file Resource
+type Option func(r *Resource)
+
+// Optional makes a missing file an empty configuration instead of an error.
+// The file is picked up by Watch once it is created, and its values are
+// dropped again when it is removed.
+func Optional() Option {
+	return func(r *Resource) {
+		r.optional = true
+	}
+}
+
+// Loose marks the values of the file as loosely typed like environment
+// variables, e.g. for .env, .ini or .properties files holding only strings:
+// keys are matched to fields ignoring case and split on "_", and strings are
+// converted to the types of the fields.
+func Loose() Option {
+	return func(r *Resource) {
+		r.loose = true
+	}
+}
+
+// Load reads and parses the configuration file and the files it includes
 func (r *Resource) Load(ctx context.Context) (*structpb.Struct, error) {
-	data, err := r.load(ctx)
+	value, _, err := r.load(ctx)
 	if err != nil {
 		return nil, err
 	}
-	r.data.Store(data)
-	return r.formatter.Parse(data)
+	r.value.Store(value)
+	return value, nil
+}
+
+// load is an internal helper to read the configuration file and its includes
+// Returns the merged value and every file read, including missing optional files
+func (r *Resource) load(ctx context.Context) (*structpb.Struct, []string, error) {
+	var files []string
+	value, err := r.loadFile(r.filename, r.formatter, r.optional, nil, &files)
+	if err != nil {
+		return nil, files, err
+	}
+	return value, files, nil
 }
 
-// load is an internal helper to read raw file content
-func (r *Resource) load(ctx context.Context) ([]byte, error) {
-	return os.ReadFile(r.filename)
+// Loose reports whether the values of the file are loosely typed, see the Loose option.
+func (r *Resource) Loose() bool {
+	return r.loose
+}
+
+// Blocks reports whether the format of the file writes a list of one object
+// as the object itself, see format.Blocks.
+func (r *Resource) Blocks() bool {
+	blocks, ok := r.formatter.(format.Blocks)
+	return ok && blocks.Blocks()
+}
+
+// String returns the name of the resource, e.g. file:/etc/app/config.yaml
+func (r *Resource) String() string {
+	return "file:" + r.filename
 }
 
 // Watch monitors the file for changes and notifies subscribers
@@ -56,22 +107,33 @@ func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, e
 		return nil, err
 	}
 
-	// Watch the directory containing the file
-	if err := fsWatcher.Add(filepath.Dir(r.filename)); err != nil {
-		return nil, err
+	// Watch the directories containing the file and the files it includes
+	files := newWatchSet(fsWatcher)
+	_, included, _ := r.load(ctx)
+	if err := files.update(append(included, r.filename)); err != nil {
+		return nil, errors.Join(err, fsWatcher.Close())
 	}
 
 	stopC := make(chan struct{})
+	doneC := make(chan struct{})
+	var stopOnce sync.Once
+	// stop terminates the watching goroutine and waits for it to exit
 	stop := func(ctx context.Context) error {
-		close(stopC)
-		return nil
+		stopOnce.Do(func() { close(stopC) })
+		select {
+		case <-doneC:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 
 	// Start watching in a separate goroutine
 	go func() {
+		defer close(doneC)
 		defer func() {
 			if err := fsWatcher.Close(); err != nil {
-				errC <- err
+				resource.Send(ctx, stopC, errC, err)
 			}
 		}()
 
@@ -85,37 +147,37 @@ func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, e
 				if !ok {
 					return
 				}
-				// Only process events for our specific file
-				if filepath.Clean(event.Name) != filepath.Clean(r.filename) {
+				// Only process events for our file and the files it includes
+				if !files.contains(event.Name) {
 					continue
 				}
-				// Only react to write/create events
-				if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+				// Only react to write/create events, and removal of optional files
+				removed := event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename)
+				if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !(r.optional && removed) {
 					continue
 				}
 				// Handle file change
-				data, err := r.load(ctx)
+				newValue, included, err := r.load(ctx)
+				if err := files.update(append(included, r.filename)); err != nil {
+					resource.Send(ctx, stopC, errC, err)
+				}
 				if err != nil {
-					errC <- err
+					resource.Send(ctx, stopC, errC, err)
 					continue
 				}
-				preData := r.data.Load()
-				if preData != nil && bytes.Equal(preData.([]byte), data) {
+				if preValue := r.value.Load(); preValue != nil && proto.Equal(preValue, newValue) {
 					continue // Skip if content hasn't changed
 				}
-				newValue, err := r.formatter.Parse(data)
-				if err != nil {
-					errC <- err
-					continue
+				if !resource.Send(ctx, stopC, notifyC, newValue) {
+					return
 				}
-				notifyC <- newValue
-				r.data.Store(data)
+				r.value.Store(newValue)
 
 			case err, ok := <-fsWatcher.Errors:
 				if !ok {
 					return
 				}
-				errC <- err
+				resource.Send(ctx, stopC, errC, err)
 			}
 		}
 	}()
@@ -125,8 +187,9 @@ func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, e
 
 // New creates a new file-based configuration resource
 // filename: Path to the configuration file
+// opts: Options such as Optional
 // Returns the Resource instance or error if initialization fails
-func New(filename string) (*Resource, error) {
+func New(filename string, opts ...Option) (*Resource, error) {
 	ext := strings.TrimPrefix(filepath.Ext(filename), ".")
 	if ext == "" {
 		return nil, fmt.Errorf("config: file extension is empty")
@@ -135,9 +198,13 @@ func New(filename string) (*Resource, error) {
 	if !ok {
 		return nil, fmt.Errorf("config: not found formatter for %s", ext)
 	}
-	return &Resource{
+	r := &Resource{
 		filename:  filename,
 		ext:       ext,
 		formatter: formatter,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
 }
@@ -10,9 +10,11 @@ import (
 	"testing"
 	"time"
 
+	_ "github.com/go-leo/config/format/hcl"
 	_ "github.com/go-leo/config/format/json"
 	_ "github.com/go-leo/config/format/yaml"
 
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -56,6 +58,34 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestLoose(t *testing.T) {
+	strict, err := New("test.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	loose, err := New("test.yaml", Loose())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strict.Loose() || !loose.Loose() {
+		t.Errorf("expected only the Loose option to mark the file loose; got %v and %v", strict.Loose(), loose.Loose())
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	yamlRsc, err := New("test.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hclRsc, err := New("test.hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if yamlRsc.Blocks() || !hclRsc.Blocks() {
+		t.Errorf("expected only hcl files to write single blocks; got %v and %v", yamlRsc.Blocks(), hclRsc.Blocks())
+	}
+}
+
 func TestLoad(t *testing.T) {
 	tempDir := t.TempDir()
 	testFile := filepath.Join(tempDir, "test.yaml")
@@ -164,3 +194,242 @@ key:
 		}
 	}
 }
+
+func TestOptional(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test.local.yaml")
+
+	resource, err := New(testFile, Optional())
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := resource.Load(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error for missing optional file: %v", err)
+	}
+	if len(value.GetFields()) != 0 {
+		t.Errorf("expected empty struct; got %v", value)
+	}
+	required, _ := New(testFile)
+	if _, err := required.Load(context.Background()); err == nil {
+		t.Error("expected error for missing required file")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	notifyC := make(chan *structpb.Struct)
+	stop, err := resource.Watch(ctx, notifyC, make(chan error, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop(ctx)
+
+	// waitFor waits for a notification whose key is expected
+	waitFor := func(expected string) {
+		for {
+			select {
+			case <-ctx.Done():
+				t.Fatalf("timeout waiting for key %q", expected)
+			case newValue := <-notifyC:
+				if newValue.GetFields()["key"].GetStringValue() == expected {
+					return
+				}
+			}
+		}
+	}
+
+	// 创建文件
+	if err := os.WriteFile(testFile, []byte("key: value"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("value")
+
+	// 删除文件
+	if err := os.Remove(testFile); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("")
+}
+
+func TestNewProfiled(t *testing.T) {
+	tempDir := t.TempDir()
+	filename := filepath.Join(tempDir, "config.yaml")
+	files := map[string]string{
+		"config.yaml":      "a: base\nb: base\nc: base",
+		"config.prod.yaml": "b: prod",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		env      string
+		profiles []string
+		expected []string
+	}{
+		{name: "Env", env: "prod", expected: []string{"config.yaml", "config.prod.yaml", "config.local.yaml"}},
+		{name: "EnvList", env: "prod, eu", expected: []string{"config.yaml", "config.prod.yaml", "config.eu.yaml", "config.local.yaml"}},
+		{name: "NoProfile", expected: []string{"config.yaml", "config.local.yaml"}},
+		{name: "Explicit", env: "prod", profiles: []string{"dev"}, expected: []string{"config.yaml", "config.dev.yaml", "config.local.yaml"}},
+		{name: "ExplicitLocal", profiles: []string{"local", "dev"}, expected: []string{"config.yaml", "config.dev.yaml", "config.local.yaml"}},
+		{name: "Repeated", profiles: []string{"dev", "prod", "dev"}, expected: []string{"config.yaml", "config.dev.yaml", "config.prod.yaml", "config.local.yaml"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ProfileEnv, tt.env)
+			resources, err := NewProfiled(filename, tt.profiles...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, r := range resources {
+				names = append(names, filepath.Base(r.(*Resource).filename))
+				// every file loads, missing overlays are empty
+				if _, err := r.Load(context.Background()); err != nil {
+					t.Errorf("unexpected error loading %s: %v", r, err)
+				}
+			}
+			if !reflect.DeepEqual(tt.expected, names) {
+				t.Errorf("expected %v; got %v", tt.expected, names)
+			}
+		})
+	}
+
+	// the profiles of the caller are not modified
+	profiles := make([]string, 1, 2)
+	profiles[0] = "dev"
+	if _, err := NewProfiled(filename, profiles...); err != nil {
+		t.Fatal(err)
+	}
+	if backing := profiles[:2]; backing[1] != "" {
+		t.Errorf("expected profiles to be unchanged, got %v", backing)
+	}
+
+	if _, err := NewProfiled(filepath.Join(tempDir, "config")); err == nil {
+		t.Error("expected error for file without extension")
+	}
+}
+
+func TestInclude(t *testing.T) {
+	writeFiles := func(t *testing.T, dir string, files map[string]string) {
+		for name, content := range files {
+			filename := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	t.Run("Merge", func(t *testing.T) {
+		tempDir := t.TempDir()
+		writeFiles(t, tempDir, map[string]string{
+			"config.yaml":      "$include: [common.yaml, redis/redis.json]\nname: app\nredis:\n  db: 2",
+			"common.yaml":      "name: common\nlevel: info",
+			"redis/redis.json": `{"$include": "auth.yaml", "redis": {"addr": "127.0.0.1:6379", "db": 1}}`,
+			"redis/auth.yaml":  "redis:\n  user: admin",
+		})
+		resource, err := New(filepath.Join(tempDir, "config.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := resource.Load(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := structpb.NewStruct(map[string]any{
+			"name":  "app",
+			"level": "info",
+			"redis": map[string]any{"addr": "127.0.0.1:6379", "db": 2, "user": "admin"},
+		})
+		if !proto.Equal(expected, value) {
+			t.Errorf("expected %v; got %v", expected, value)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			files     map[string]string
+			expectErr string
+		}{
+			{
+				name:      "Cycle",
+				files:     map[string]string{"config.yaml": "$include: a.yaml", "a.yaml": "$include: b.yaml", "b.yaml": "$include: a.yaml"},
+				expectErr: "config: include cycle: ",
+			},
+			{
+				name:      "Missing",
+				files:     map[string]string{"config.yaml": "$include: missing.yaml"},
+				expectErr: "missing.yaml",
+			},
+			{
+				name:      "NotFileName",
+				files:     map[string]string{"config.yaml": "$include: {a: b}"},
+				expectErr: "must be a file name or a list of file names",
+			},
+			{
+				name:      "UnknownFormat",
+				files:     map[string]string{"config.yaml": "$include: a.txt", "a.txt": ""},
+				expectErr: "not found formatter for txt",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tempDir := t.TempDir()
+				writeFiles(t, tempDir, tt.files)
+				resource, err := New(filepath.Join(tempDir, "config.yaml"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := resource.Load(context.Background()); err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected error %q; got %v", tt.expectErr, err)
+				}
+			})
+		}
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		tempDir := t.TempDir()
+		writeFiles(t, tempDir, map[string]string{
+			"config.yaml":      "$include: redis/redis.yaml\nname: app",
+			"redis/redis.yaml": "addr: 127.0.0.1:6379",
+		})
+		resource, err := New(filepath.Join(tempDir, "config.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := resource.Load(ctx); err != nil {
+			t.Fatal(err)
+		}
+		notifyC := make(chan *structpb.Struct)
+		stop, err := resource.Watch(ctx, notifyC, make(chan error, 10))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer stop(ctx)
+
+		// 修改被包含的文件
+		writeFiles(t, tempDir, map[string]string{"redis/redis.yaml": "addr: 10.0.0.1:6379"})
+		for {
+			select {
+			case <-ctx.Done():
+				t.Fatal("timeout waiting for included file update")
+			case newValue := <-notifyC:
+				if newValue.GetFields()["addr"].GetStringValue() == "10.0.0.1:6379" {
+					if newValue.GetFields()["name"].GetStringValue() != "app" {
+						t.Errorf("expected name 'app'; got %v", newValue)
+					}
+					return
+				}
+			}
+		}
+	})
+}
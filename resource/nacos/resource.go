@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/go-leo/config/format"
@@ -52,12 +53,19 @@ func (r *Resource) load(ctx context.Context) ([]byte, error) {
 	return []byte(content), nil
 }
 
+// String returns the name of the resource, e.g. nacos:DEFAULT_GROUP/config.yaml
+func (r *Resource) String() string {
+	return "nacos:" + r.group + "/" + r.dataId
+}
+
 // Watch monitors configuration changes in Nacos and notifies through channels
 // Returns a stop function to cancel the watch and any initialization error
 func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(ctx context.Context) error, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
+	stopC := make(chan struct{})
+	doneC := make(chan struct{})
 	err := r.client.ListenConfig(vo.ConfigParam{
 		Group:  r.group,
 		DataId: r.dataId,
@@ -70,18 +78,21 @@ func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, e
 			}
 			newValue, err := r.formatter.Parse(data)
 			if err != nil {
-				errC <- err
+				send(ctx, stopC, errC, err)
+				return
+			}
+			if !send(ctx, stopC, notifyC, newValue) {
 				return
 			}
-			notifyC <- newValue
 			r.data.Store(data)
 		},
 	})
 	if err != nil {
 		return nil, err
 	}
-	stopC := make(chan struct{})
+	var cancelErr error
 	go func() {
+		defer close(doneC)
 		select {
 		case <-ctx.Done():
 		case <-stopC:
@@ -91,17 +102,38 @@ func (r *Resource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, e
 			DataId: r.dataId,
 		})
 		if err != nil {
-			errC <- err
+			cancelErr = err
+			send(ctx, stopC, errC, err)
 			return
 		}
 	}()
+	var stopOnce sync.Once
+	// stop cancels the listener and waits for the cancellation to finish
 	stop := func(ctx context.Context) error {
-		close(stopC)
-		return nil
+		stopOnce.Do(func() { close(stopC) })
+		select {
+		case <-doneC:
+			return cancelErr
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 	return stop, nil
 }
 
+// send delivers v to c unless ctx is done or stopC is closed first.
+// Returns false if v was not delivered.
+func send[T any](ctx context.Context, stopC <-chan struct{}, c chan<- T, v T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-stopC:
+		return false
+	case c <- v:
+		return true
+	}
+}
+
 // New creates a new Nacos configuration resource
 // Validates the dataId extension and finds appropriate formatter
 func New(client config_client.IConfigClient, group string, dataId string) (*Resource, error) {
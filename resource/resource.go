@@ -2,6 +2,7 @@ package resource
 
 import (
 	"context"
+	"fmt"
 
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -30,3 +31,58 @@ type Resource interface {
 	//   - error: Immediate error if watch setup fails
 	Watch(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error)
 }
+
+// Name returns a human readable name of the resource, e.g. file:/etc/app/config.yaml,
+// used to report where configuration values come from.
+// Resources implementing fmt.Stringer are named by their String method,
+// others by their type.
+func Name(r Resource) string {
+	if stringer, ok := r.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%T", r)
+}
+
+// Loose is an optional interface implemented by resources whose values are
+// loosely typed text, such as environment variables. Their keys are matched
+// to fields ignoring case and split on "_" to reach nested fields, and their
+// strings are converted to the types of the fields. The keys of other
+// resources must be the proto or JSON names of the fields.
+type Loose interface {
+	// Loose reports whether the values of the resource are loosely typed.
+	Loose() bool
+}
+
+// IsLoose reports whether the values of r are loosely typed, see Loose.
+func IsLoose(r Resource) bool {
+	loose, ok := r.(Loose)
+	return ok && loose.Loose()
+}
+
+// Blocks is an optional interface implemented by resources whose format
+// writes a list of one object as the object itself, such as a single HCL
+// block. Their objects fill repeated message fields as lists of one item.
+type Blocks interface {
+	// Blocks reports whether a single object stands for a list of one object.
+	Blocks() bool
+}
+
+// IsBlocks reports whether a single object of r stands for a list of one object, see Blocks.
+func IsBlocks(r Resource) bool {
+	blocks, ok := r.(Blocks)
+	return ok && blocks.Blocks()
+}
+
+// Send delivers v to c unless ctx is done or stopC is closed first, for the
+// watching goroutines of resources.
+// Returns false if v was not delivered.
+func Send[T any](ctx context.Context, stopC <-chan struct{}, c chan<- T, v T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-stopC:
+		return false
+	case c <- v:
+		return true
+	}
+}
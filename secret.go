@@ -0,0 +1,36 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-leo/config/secret"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// resolveSecrets returns a copy of value where every secret reference,
+// e.g. secret://file/run/secrets/redis, is replaced by the secret returned
+// by the resolver registered for its scheme.
+func resolveSecrets(ctx context.Context, value *structpb.Struct) (*structpb.Struct, error) {
+	value = proto.Clone(value).(*structpb.Struct)
+	err := replaceStrings("", structpb.NewStructValue(value), func(path string, s string) (string, error) {
+		scheme, ref, ok := secret.Parse(s)
+		if !ok {
+			return s, nil
+		}
+		resolver, ok := secret.GetResolver(scheme)
+		if !ok {
+			return "", fmt.Errorf("config: not found secret resolver for %s, in %s", scheme, path)
+		}
+		resolved, err := resolver.Resolve(ctx, ref)
+		if err != nil {
+			return "", fmt.Errorf("config: failed to resolve secret of %s: %w", path, err)
+		}
+		return resolved, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
@@ -0,0 +1,39 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-leo/config/secret"
+)
+
+// init registers the env resolver with the global secret registry.
+func init() {
+	secret.RegisterResolver("env", Resolver{})
+}
+
+// Resolver implements the secret.Resolver interface for secrets stored in
+// environment variables, e.g. secret://env/REDIS_PASSWORD.
+type Resolver struct{}
+
+// Resolve reads the secret from the environment variable named by ref.
+//
+// Args:
+//
+// ctx context.Context: Unused
+// ref string: Name of the environment variable, with a leading "/"
+//
+// Returns:
+//
+// string: Value of the environment variable
+// error: Error if the environment variable is not set
+func (Resolver) Resolve(ctx context.Context, ref string) (string, error) {
+	name := strings.TrimPrefix(ref, "/")
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("config: environment variable %s is not set", name)
+	}
+	return value, nil
+}
@@ -0,0 +1,20 @@
+package env
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	t.Setenv("TEST_REDIS_PASSWORD", "s3cr3t")
+	value, err := Resolver{}.Resolve(context.Background(), "/TEST_REDIS_PASSWORD")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if value != "s3cr3t" {
+		t.Errorf("Expected 's3cr3t', got %q", value)
+	}
+	if _, err := (Resolver{}).Resolve(context.Background(), "/TEST_MISSING_PASSWORD"); err == nil {
+		t.Error("Expected error for missing variable, got nil")
+	}
+}
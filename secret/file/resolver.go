@@ -0,0 +1,39 @@
+package file
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/go-leo/config/secret"
+)
+
+// init registers the file resolver with the global secret registry.
+func init() {
+	secret.RegisterResolver("file", Resolver{})
+}
+
+// Resolver implements the secret.Resolver interface for secrets stored in
+// files, such as Docker or Kubernetes mounted secrets,
+// e.g. secret://file/run/secrets/redis.
+type Resolver struct{}
+
+// Resolve reads the secret from the file at ref.
+// Trailing line breaks are removed, as most tools write them after the secret.
+//
+// Args:
+//
+// ctx context.Context: Unused
+// ref string: Path of the file
+//
+// Returns:
+//
+// string: Content of the file
+// error: Error encountered while reading the file
+func (Resolver) Resolve(ctx context.Context, ref string) (string, error) {
+	data, err := os.ReadFile(ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
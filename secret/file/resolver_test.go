@@ -0,0 +1,25 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "redis")
+	if err := os.WriteFile(filename, []byte("s3cr3t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	value, err := Resolver{}.Resolve(context.Background(), filename)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if value != "s3cr3t" {
+		t.Errorf("Expected 's3cr3t', got %q", value)
+	}
+	if _, err := (Resolver{}).Resolve(context.Background(), filename+".missing"); err == nil {
+		t.Error("Expected error for missing file, got nil")
+	}
+}
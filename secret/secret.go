@@ -0,0 +1,88 @@
+package secret
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Prefix starts every secret reference, e.g. secret://file/run/secrets/redis
+// refers to the secret resolved by the "file" resolver with the reference
+// /run/secrets/redis.
+const Prefix = "secret://"
+
+// Global resolvers registry mapping schemes to their resolvers
+var (
+	// resolvers stores registered secret resolvers
+	resolvers = make(map[string]Resolver)
+	// mutex to protect concurrent access to resolvers
+	mutex sync.RWMutex
+)
+
+// Resolver interface defines the standard method for resolving secret references
+type Resolver interface {
+	// Resolve returns the secret identified by ref
+	//
+	// Args:
+	//   ctx (context.Context): Context for controlling the resolution
+	//   ref (string): Reference after the scheme, e.g. /run/secrets/redis
+	//
+	// Returns:
+	//   string: The secret
+	//   error: Error if the secret cannot be resolved
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// RegisterResolver associates a scheme with a secret resolver
+//
+// Args:
+//
+//	scheme (string): Scheme of the references (e.g., "file", "vault")
+//	resolver (Resolver): Implementation of the Resolver interface
+func RegisterResolver(scheme string, resolver Resolver) {
+	mutex.Lock()
+	resolvers[strings.ToLower(scheme)] = resolver
+	mutex.Unlock()
+}
+
+// GetResolver retrieves the resolver associated with a specific scheme
+//
+// Args:
+//
+//	scheme (string): Scheme to look up
+//
+// Returns:
+//
+//	Resolver: Registered resolver or nil if not found
+func GetResolver(scheme string) (Resolver, bool) {
+	mutex.RLock()
+	resolver, ok := resolvers[strings.ToLower(scheme)]
+	mutex.RUnlock()
+	return resolver, ok
+}
+
+// Parse splits a secret reference into its scheme and reference
+//
+// Args:
+//
+//	value (string): Value such as secret://file/run/secrets/redis
+//
+// Returns:
+//
+//	scheme (string): Scheme of the resolver, e.g. file
+//	ref (string): Reference passed to the resolver, e.g. /run/secrets/redis
+//	ok (bool): False if value is not a secret reference
+func Parse(value string) (scheme string, ref string, ok bool) {
+	rest, ok := strings.CutPrefix(value, Prefix)
+	if !ok {
+		return "", "", false
+	}
+	scheme, ref = rest, ""
+	if index := strings.IndexByte(rest, '/'); index >= 0 {
+		scheme, ref = rest[:index], rest[index:]
+	}
+	if scheme == "" {
+		return "", "", false
+	}
+	return scheme, ref, true
+}
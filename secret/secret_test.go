@@ -0,0 +1,52 @@
+package secret
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value  string
+		scheme string
+		ref    string
+		ok     bool
+	}{
+		{value: "secret://file/run/secrets/redis", scheme: "file", ref: "/run/secrets/redis", ok: true},
+		{value: "secret://env/REDIS_PASSWORD", scheme: "env", ref: "/REDIS_PASSWORD", ok: true},
+		{value: "secret://vault", scheme: "vault", ok: true},
+		{value: "secret:///run/secrets/redis"},
+		{value: "secret://"},
+		{value: "password"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			scheme, ref, ok := Parse(tt.value)
+			if scheme != tt.scheme || ref != tt.ref || ok != tt.ok {
+				t.Errorf("Expected (%q, %q, %v), got (%q, %q, %v)", tt.scheme, tt.ref, tt.ok, scheme, ref, ok)
+			}
+		})
+	}
+}
+
+type resolverFunc func(ctx context.Context, ref string) (string, error)
+
+func (f resolverFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+func TestRegisterResolver(t *testing.T) {
+	RegisterResolver("Test", resolverFunc(func(ctx context.Context, ref string) (string, error) {
+		return ref, nil
+	}))
+	resolver, ok := GetResolver("test")
+	if !ok {
+		t.Fatal("Expected resolver to be registered")
+	}
+	if value, _ := resolver.Resolve(context.Background(), "/a"); value != "/a" {
+		t.Errorf("Expected '/a', got %q", value)
+	}
+	if _, ok := GetResolver("unknown"); ok {
+		t.Error("Expected no resolver for unknown scheme")
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-leo/config/resource"
+	"github.com/go-leo/config/secret"
+	"github.com/go-leo/config/test"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// secretResolverFunc adapts a function to secret.Resolver
+type secretResolverFunc func(ctx context.Context, ref string) (string, error)
+
+func (f secretResolverFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+func TestLoadSecrets(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "redis")
+	if err := os.WriteFile(filename, []byte("s3cr3t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	testStruct, _ := structpb.NewStruct(map[string]interface{}{"field1": "secret://file" + filename, "field2": "plain"})
+	result, err := Load[*test.Config](context.Background(), []resource.Resource{&mockLoadResource{value: testStruct}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Field1 != "s3cr3t" || result.Field2 != "plain" {
+		t.Errorf("Expected 's3cr3t' and 'plain', got '%s' and '%s'", result.Field1, result.Field2)
+	}
+	if testStruct.GetFields()["field1"].GetStringValue() != "secret://file"+filename {
+		t.Errorf("Expected resource value to be unchanged, got %v", testStruct)
+	}
+
+	testStruct, _ = structpb.NewStruct(map[string]interface{}{"field1": "secret://unknown/redis"})
+	if _, err := Load[*test.Config](context.Background(), []resource.Resource{&mockLoadResource{value: testStruct}}); err == nil {
+		t.Error("Expected error for unknown scheme, got nil")
+	}
+}
+
+func TestWatchSecrets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// rotating secret, every resolution returns a new version
+	var version atomic.Int32
+	secret.RegisterResolver("rotating", secretResolverFunc(func(ctx context.Context, ref string) (string, error) {
+		return ref + "-v" + strconv.Itoa(int(version.Add(1))), nil
+	}))
+
+	notifyC := make(chan Event[*test.Config], 1)
+	changeC := make(chan *structpb.Struct)
+	res := &mockResource{
+		watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+			go func() {
+				for v := range changeC {
+					notifyC <- v
+				}
+			}()
+			return func(ctx context.Context) error { return nil }, nil
+		},
+	}
+	watcher, err := Watch[*test.Config](ctx, notifyC, make(chan error, 1), []resource.Resource{res}, WithDebounce(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer watcher.Close(ctx)
+	defer close(changeC)
+
+	for _, expected := range []string{"/redis-v1", "/redis-v2"} {
+		v, _ := structpb.NewStruct(map[string]any{"field1": "secret://rotating/redis"})
+		changeC <- v
+		select {
+		case event := <-notifyC:
+			if event.New.GetField1() != expected {
+				t.Errorf("Expected %s, got %v", expected, event.New)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timeout waiting for notification")
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-leo/config/merge"
+	"github.com/go-leo/config/resource"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrWatching is returned by Store.Watch when the store is already watching.
+var ErrWatching = errors.New("config: store is already watching")
+
+// Store holds the current value of a configuration. Reads are lock free,
+// so Get can be called on hot paths. Independent stores can be created for
+// the same configuration type, e.g. one per tenant or per test.
+type Store[Config proto.Message] struct {
+	// value the current configuration
+	value atomic.Pointer[Config]
+	// mutex guards subscribers and watcher
+	mutex sync.Mutex
+	// subscribers are called after every update
+	subscribers []*subscriber[Config]
+	// watcher is the running Watch, nil if the store is not watching
+	watcher *Watcher
+	// resources of the last successful Load
+	resources []resource.Resource
+	// layers are the values loaded from resources by the last successful Load
+	layers []merge.Layer
+}
+
+// subscriber wraps a subscription function, so that it can be removed.
+type subscriber[Config proto.Message] struct {
+	fn func(old, new Config)
+}
+
+// NewStore creates a store holding initial.
+func NewStore[Config proto.Message](initial Config) *Store[Config] {
+	s := &Store[Config]{}
+	s.value.Store(&initial)
+	return s
+}
+
+// Get returns the current configuration. The returned message is shared and
+// must not be modified.
+func (s *Store[Config]) Get() Config {
+	if p := s.value.Load(); p != nil {
+		return *p
+	}
+	var config Config
+	return config
+}
+
+// Set replaces the current configuration and calls the subscribers with the
+// previous and the new configuration. The store keeps conf, it must not be
+// modified afterwards.
+func (s *Store[Config]) Set(conf Config) {
+	var old Config
+	if p := s.value.Swap(&conf); p != nil {
+		old = *p
+	}
+	s.mutex.Lock()
+	subscribers := s.subscribers
+	s.mutex.Unlock()
+	for _, sub := range subscribers {
+		sub.fn(old, conf)
+	}
+}
+
+// Subscribe registers fn to be called after every update, in the order of
+// subscription. It returns a function that removes the subscription.
+func (s *Store[Config]) Subscribe(fn func(old, new Config)) func() {
+	sub := &subscriber[Config]{fn: fn}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.subscribers = append(s.subscribers[:len(s.subscribers):len(s.subscribers)], sub)
+	return func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		subscribers := make([]*subscriber[Config], 0, len(s.subscribers))
+		for _, other := range s.subscribers {
+			if other != sub {
+				subscribers = append(subscribers, other)
+			}
+		}
+		s.subscribers = subscribers
+	}
+}
+
+// Load loads the configuration from resources and stores it. A following
+// Watch of the same resources starts from the loaded values, so that it does
+// not load the resources again.
+func (s *Store[Config]) Load(ctx context.Context, resources []resource.Resource, opts ...Option) error {
+	conf, layers, err := load[Config](ctx, resources, newOptions(opts...))
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	s.resources, s.layers = resources, layers
+	s.mutex.Unlock()
+	s.Set(conf)
+	return nil
+}
+
+// Watch watches resources and stores every updated configuration.
+// The first update is compared with the current configuration of the store.
+// The returned event channel receives an event after every update; if the
+// receiver is behind, pending events are merged into one. The returned error
+// channel receives the errors raised while watching, e.g. a reload failing
+// validation, unless handled by WithErrorHandler; if the receiver is behind,
+// only the latest error is kept. Both channels are closed once the store is
+// closed or ctx is done.
+func (s *Store[Config]) Watch(ctx context.Context, resources []resource.Resource, opts ...Option) (<-chan Event[Config], <-chan error, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.watcher != nil {
+		select {
+		case <-s.watcher.Done():
+		default:
+			return nil, nil, ErrWatching
+		}
+	}
+	notifyC := make(chan Event[Config])
+	errC := make(chan error, 1)
+	forward := func(err error) {
+		select {
+		case errC <- err:
+		default:
+			// the receiver is behind, replace the pending error
+			select {
+			case <-errC:
+			default:
+			}
+			select {
+			case errC <- err:
+			default:
+			}
+		}
+	}
+	opts = append([]Option{WithErrorHandler(forward), WithInitial(s.Get())}, opts...)
+	if slices.Equal(s.resources, resources) {
+		opts = append([]Option{withLayers(s.layers)}, opts...)
+	}
+	watcher, err := Watch[Config](ctx, notifyC, nil, resources, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.watcher = watcher
+	changedC := make(chan Event[Config], 1)
+	go func() {
+		defer close(changedC)
+		// the error handler is not called any more once the watcher is done
+		defer close(errC)
+		for {
+			select {
+			case <-watcher.Done():
+				return
+			case event := <-notifyC:
+				s.Set(event.New)
+				select {
+				case changedC <- event:
+				default:
+					// the receiver is behind, merge with the pending event
+					select {
+					case pending := <-changedC:
+						event = pending.Merge(event)
+					default:
+					}
+					changedC <- event
+				}
+			}
+		}
+	}()
+	return changedC, errC, nil
+}
+
+// Close stops watching and waits until the watcher has shut down or ctx is done.
+// The current configuration stays available.
+func (s *Store[Config]) Close(ctx context.Context) error {
+	s.mutex.Lock()
+	watcher := s.watcher
+	s.watcher = nil
+	s.mutex.Unlock()
+	if watcher == nil {
+		return nil
+	}
+	return watcher.Close(ctx)
+}
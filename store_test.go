@@ -0,0 +1,237 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-leo/config/resource"
+	"github.com/go-leo/config/test"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestStore(t *testing.T) {
+	t.Run("GetSet", func(t *testing.T) {
+		initial := &test.Config{Field1: "initial"}
+		s := NewStore(initial)
+		if s.Get() != initial {
+			t.Errorf("Expected %v, got %v", initial, s.Get())
+		}
+		// stores are independent
+		other := NewStore(&test.Config{})
+		conf := &test.Config{Field1: "value1"}
+		s.Set(conf)
+		if s.Get() != conf {
+			t.Errorf("Expected %v, got %v", conf, s.Get())
+		}
+		if other.Get().GetField1() != "" {
+			t.Errorf("Expected other store to be unchanged, got %v", other.Get())
+		}
+	})
+
+	t.Run("Subscribe", func(t *testing.T) {
+		initial := &test.Config{Field1: "initial"}
+		s := NewStore(initial)
+		var calls [][2]*test.Config
+		unsubscribe := s.Subscribe(func(old, new *test.Config) {
+			calls = append(calls, [2]*test.Config{old, new})
+		})
+		conf1 := &test.Config{Field1: "value1"}
+		s.Set(conf1)
+		unsubscribe()
+		s.Set(&test.Config{Field1: "value2"})
+		if len(calls) != 1 || calls[0][0] != initial || calls[0][1] != conf1 {
+			t.Errorf("Expected one call from initial to value1, got %v", calls)
+		}
+	})
+
+	t.Run("Load", func(t *testing.T) {
+		testStruct, _ := structpb.NewStruct(map[string]interface{}{"field1": "value1"})
+		s := NewStore(&test.Config{})
+		if err := s.Load(context.Background(), []resource.Resource{&mockLoadResource{value: testStruct}}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if s.Get().GetField1() != "value1" {
+			t.Errorf("Expected 'value1', got %v", s.Get())
+		}
+
+		expectedErr := errors.New("load error")
+		if err := s.Load(context.Background(), []resource.Resource{&mockLoadResource{err: expectedErr}}); !errors.Is(err, expectedErr) {
+			t.Errorf("Expected error %v, got %v", expectedErr, err)
+		}
+		if s.Get().GetField1() != "value1" {
+			t.Errorf("Expected failed load to keep 'value1', got %v", s.Get())
+		}
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		ctx := context.Background()
+		changeC := make(chan *structpb.Struct)
+		defer close(changeC)
+		res := &mockResource{
+			watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+				go func() {
+					for v := range changeC {
+						notifyC <- v
+					}
+				}()
+				return func(ctx context.Context) error { return nil }, nil
+			},
+		}
+		s := NewStore(&test.Config{})
+		var subscribed *test.Config
+		s.Subscribe(func(old, new *test.Config) { subscribed = new })
+		changedC, errC, err := s.Watch(ctx, []resource.Resource{res}, WithDebounce(time.Millisecond))
+		if err != nil {
+			t.Fatalf("Watch failed: %v", err)
+		}
+		if _, _, err := s.Watch(ctx, []resource.Resource{res}); !errors.Is(err, ErrWatching) {
+			t.Errorf("Expected %v, got %v", ErrWatching, err)
+		}
+
+		v, _ := structpb.NewStruct(map[string]any{"field1": "value1"})
+		changeC <- v
+		select {
+		case event := <-changedC:
+			if event.New.GetField1() != "value1" || s.Get() != event.New || subscribed != event.New {
+				t.Errorf("Expected store and subscriber to hold %v, got %v and %v", event.New, s.Get(), subscribed)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timeout waiting for notification")
+		}
+
+		if err := s.Close(ctx); err != nil {
+			t.Errorf("Unexpected close error: %v", err)
+		}
+		select {
+		case _, ok := <-changedC:
+			if ok {
+				t.Error("Expected channel to be closed")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timeout waiting for channel to be closed")
+		}
+		if _, ok := <-errC; ok {
+			t.Error("Expected error channel to be closed")
+		}
+		if s.Get().GetField1() != "value1" {
+			t.Errorf("Expected closed store to keep 'value1', got %v", s.Get())
+		}
+	})
+
+	t.Run("WatchFromCurrent", func(t *testing.T) {
+		ctx := context.Background()
+		changeC := make(chan *structpb.Struct)
+		defer close(changeC)
+		res := &mockResource{
+			watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+				go func() {
+					for v := range changeC {
+						notifyC <- v
+					}
+				}()
+				return func(ctx context.Context) error { return nil }, nil
+			},
+		}
+		loaded := &test.Config{Field1: "value1", Field2: "value2"}
+		s := NewStore(loaded)
+		changedC, errC, err := s.Watch(ctx, []resource.Resource{res}, WithDebounce(time.Millisecond),
+			WithValidator(func(conf *test.Config) error {
+				if conf.GetField1() == "" {
+					return errors.New("field1 is required")
+				}
+				return nil
+			}))
+		if err != nil {
+			t.Fatalf("Watch failed: %v", err)
+		}
+		defer s.Close(ctx)
+
+		// A failed reload is reported on the error channel and keeps the config
+		v, _ := structpb.NewStruct(map[string]any{"field2": "value2"})
+		changeC <- v
+		select {
+		case err := <-errC:
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Expected validation error, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timeout waiting for error")
+		}
+		if s.Get() != loaded {
+			t.Errorf("Expected failed reload to keep %v, got %v", loaded, s.Get())
+		}
+
+		// The first event is compared with the current config of the store
+		v, _ = structpb.NewStruct(map[string]any{"field1": "value3", "field2": "value2"})
+		changeC <- v
+		select {
+		case event := <-changedC:
+			if event.Old != loaded {
+				t.Errorf("Expected old config %v, got %v", loaded, event.Old)
+			}
+			if paths := event.Changed.GetPaths(); len(paths) != 1 || paths[0] != "field1" {
+				t.Errorf("Expected only field1 to change, got %v", paths)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timeout waiting for notification")
+		}
+	})
+
+	t.Run("WatchAfterLoad", func(t *testing.T) {
+		ctx := context.Background()
+		changeC := make(chan *structpb.Struct)
+		defer close(changeC)
+		fileRes := &mockResource{
+			watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+				go func() {
+					for v := range changeC {
+						notifyC <- v
+					}
+				}()
+				return func(ctx context.Context) error { return nil }, nil
+			},
+			loadFunc: func(ctx context.Context) (*structpb.Struct, error) {
+				return structpb.NewStruct(map[string]any{"field1": "value1"})
+			},
+		}
+		var remoteLoads atomic.Int32
+		remoteRes := &mockResource{
+			watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+				return func(ctx context.Context) error { return nil }, nil
+			},
+			loadFunc: func(ctx context.Context) (*structpb.Struct, error) {
+				remoteLoads.Add(1)
+				return structpb.NewStruct(map[string]any{"field2": "remote"})
+			},
+		}
+		resources := []resource.Resource{fileRes, remoteRes}
+		s := NewStore(&test.Config{})
+		if err := s.Load(ctx, resources); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		changedC, _, err := s.Watch(ctx, resources, WithDebounce(time.Millisecond))
+		if err != nil {
+			t.Fatalf("Watch failed: %v", err)
+		}
+		defer s.Close(ctx)
+
+		// the change of one resource does not load the other again
+		v, _ := structpb.NewStruct(map[string]any{"field1": "value2"})
+		changeC <- v
+		select {
+		case event := <-changedC:
+			if event.New.GetField1() != "value2" || event.New.GetField2() != "remote" {
+				t.Errorf("Expected value2 and remote, got %v", event.New)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timeout waiting for notification")
+		}
+		if n := remoteLoads.Load(); n != 1 {
+			t.Errorf("Expected remote to be loaded once by Load, got %d loads", n)
+		}
+	})
+}
@@ -2,6 +2,7 @@
 
 protoc \
 --proto_path=. \
+--proto_path=../proto \
 --go_out=. \
 --go_opt=paths=source_relative \
 ./*.proto
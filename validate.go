@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ValidationError is returned by Load and reported by Watch when a
+// configuration was loaded successfully but failed validation.
+// Watch keeps the previous configuration active in that case.
+type ValidationError struct {
+	// Err is the error returned by the failing validator
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("config: invalid configuration: %v", e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ProtoValidator validates messages against constraints declared on the proto,
+// e.g. a protovalidate validator.
+type ProtoValidator interface {
+	Validate(msg proto.Message) error
+}
+
+// WithValidator adds a function that validates the loaded configuration.
+// Validators run in the order they are added, after the configuration has been
+// unmarshalled, on Load and on every reload in Watch. A validator of another
+// type than the loaded configuration fails validation.
+func WithValidator[Config proto.Message](validate func(Config) error) Option {
+	return func(o *options) {
+		o.validators = append(o.validators, func(msg proto.Message) error {
+			conf, ok := msg.(Config)
+			if !ok {
+				var want Config
+				return fmt.Errorf("config: validator for %T applied to %T", want, msg)
+			}
+			return validate(conf)
+		})
+	}
+}
+
+// WithProtoValidator adds a validator of constraints declared on the proto.
+func WithProtoValidator(validator ProtoValidator) Option {
+	return func(o *options) {
+		o.validators = append(o.validators, validator.Validate)
+	}
+}
+
+// validate runs the Validate method generated by protoc-gen-validate if the
+// message has one, then the configured validators.
+func validate(msg proto.Message, o *options) error {
+	if v, ok := msg.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return &ValidationError{Err: err}
+		}
+	}
+	for _, validator := range o.validators {
+		if err := validator(msg); err != nil {
+			return &ValidationError{Err: err}
+		}
+	}
+	return nil
+}
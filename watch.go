@@ -4,56 +4,167 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/go-leo/config/merge"
 	"github.com/go-leo/config/resource"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// ErrClosed is returned by Watcher.Err after the watcher has been closed.
+var ErrClosed = errors.New("config: watcher closed")
+
+// Watcher controls the lifecycle of a running Watch.
+type Watcher struct {
+	// cancel stops every goroutine started by Watch
+	cancel context.CancelFunc
+	// stops functions of the resource watchers
+	stops []func(context.Context) error
+	// stopOnce guards the stops being called only once
+	stopOnce sync.Once
+	// stopErr combined error of the stops
+	stopErr error
+	// wg tracks the goroutines started by Watch
+	wg sync.WaitGroup
+	// done is closed once every goroutine has exited
+	done chan struct{}
+	// err is the reason the watcher is done
+	err error
+	// closed reports whether Close was called
+	closed atomic.Bool
+}
+
+// Close stops every resource watcher, the reload loop and the fan-in of
+// notifications, and waits until all of them have exited or ctx is done.
+// It returns the errors of the resource watchers' stop functions.
+func (w *Watcher) Close(ctx context.Context) error {
+	w.closed.Store(true)
+	w.stop(ctx)
+	select {
+	case <-w.done:
+		return w.stopErr
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed once the watcher has shut down,
+// either by Close or by cancellation of the context passed to Watch.
+func (w *Watcher) Done() <-chan struct{} {
+	return w.done
+}
+
+// Err returns nil until Done is closed. Afterwards it returns ErrClosed if
+// the watcher was closed, or the error of the context passed to Watch,
+// joined with any error of stopping the resource watchers.
+func (w *Watcher) Err() error {
+	select {
+	case <-w.done:
+		return w.err
+	default:
+		return nil
+	}
+}
+
+// stop stops the resource watchers and cancels the goroutines, only once.
+func (w *Watcher) stop(ctx context.Context) {
+	w.stopOnce.Do(func() {
+		var errs []error
+		for _, stop := range w.stops {
+			if err := stop(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		w.stopErr = errors.Join(errs...)
+		w.cancel()
+	})
+}
+
+// shutdown waits for the context to be done, then tears down the watcher.
+func (w *Watcher) shutdown(parent context.Context, ctx context.Context) {
+	<-ctx.Done()
+	w.stop(context.Background())
+	w.wg.Wait()
+	cause := parent.Err()
+	if w.closed.Load() {
+		cause = ErrClosed
+	}
+	w.err = errors.Join(cause, w.stopErr)
+	close(w.done)
+}
+
 // Watch continuously monitors configuration resources for changes and sends updates
-// to the provided channels. It handles:
+// to the provided channels. Every update carries the previous and the new
+// configuration together with the changed field paths, reloads that change
+// nothing are not sent. It handles:
 // - Merging notifications from multiple sources
 // - Debouncing changes and periodically reloading configurations
-// - Proper cleanup via returned stop function
+// - Re-merging cached values, replacing only the value of the resource that changed
+// - Proper cleanup via the returned Watcher
 //
 // Parameters:
 //
 //	ctx      - Context for cancellation and timeout control
-//	notifyC  - Channel to receive configuration updates (protobuf messages)
+//	notifyC  - Channel to receive configuration updates (old, new and changed paths)
 //	errC     - Channel to receive any errors during watching
-//	resources - Variadic list of configuration resources to watch
+//	resources - Configuration resources to watch, later resources take precedence
+//	opts      - Options customizing merging, debouncing and error handling
 //
 // Returns:
 //
-//	stop function - Call to clean up all watchers (returns combined errors if any)
-//	error        - Initial error if watching failed to start
-func Watch[Config proto.Message](ctx context.Context, notifyC chan<- Config, errC chan<- error, resources ...resource.Resource) (func(context.Context) error, error) {
-	// Channels from individual resource watchers
-	var notifyCs []chan *structpb.Struct
-	// Stop functions for each watcher
-	var stops []func(context.Context) error
+//	*Watcher - Controls the lifecycle, Close it to clean up all watchers and goroutines
+//	error    - Initial error if watching failed to start
+func Watch[Config proto.Message](ctx context.Context, notifyC chan<- Event[Config], errC chan<- error, resources []resource.Resource, opts ...Option) (*Watcher, error) {
+	o := newOptions(opts...)
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	w := &Watcher{cancel: cancel, done: make(chan struct{})}
 
-	// Combined stop function that stops all individual watchers
-	stop := func(context.Context) error {
-		var errs []error
-		for _, stop := range stops {
-			if err := stop(ctx); err != nil {
-				errs = append(errs, err)
+	// Errors are delivered to the error handler if any, otherwise to errC
+	handleError := o.errorHandler
+	if handleError == nil {
+		handleError = func(err error) {
+			select {
+			case <-ctx.Done():
+			case errC <- err:
 			}
 		}
-		return errors.Join(errs...)
 	}
 
+	// Errors raised by individual resource watchers
+	watchErrC := make(chan error)
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-watchErrC:
+				handleError(err)
+			}
+		}
+	}()
+
+	// Channels from individual resource watchers
+	var notifyCs []chan *structpb.Struct
+
 	// Start watching each resource
 	for _, watcher := range resources {
 		notifyC := make(chan *structpb.Struct, cap(notifyC))
-		stop, err := watcher.Watch(ctx, notifyC, errC)
+		stop, err := watcher.Watch(ctx, notifyC, watchErrC)
 		if err != nil {
-			return nil, errors.Join(err, stop(ctx))
+			if stop != nil {
+				err = errors.Join(err, stop(ctx))
+			}
+			w.stop(ctx)
+			w.wg.Wait()
+			return nil, errors.Join(err, w.stopErr)
 		}
 		notifyCs = append(notifyCs, notifyC)
-		stops = append(stops, stop)
+		w.stops = append(w.stops, stop)
 	}
 
 	// Merge notifications from all watchers into single channel
@@ -65,34 +176,133 @@ func Watch[Config proto.Message](ctx context.Context, notifyC chan<- Config, err
 		)...,
 	)
 
+	// Last value of every resource, from the values already loaded if given,
+	// otherwise loaded on demand by the first reload
+	layers := make([]merge.Layer, len(resources))
+	for i, r := range resources {
+		layers[i].Source = resource.Name(r)
+		if len(o.layers) == len(resources) {
+			layers[i].Value = o.layers[i].Value
+		}
+	}
+
 	// Monitor for changes and reload configurations
+	w.wg.Add(1)
 	go func() {
-		ticker := time.NewTicker(time.Second)
-		var changed bool
+		defer w.wg.Done()
+		var s schedule
+		defer s.stop()
+		// Last configuration sent, the initial configuration until the first update
+		var current Config
+		if initial, ok := o.initial.(Config); ok {
+			current = initial
+		}
+		// Wait for the fan-in to exit, it closes mergedC once done
+		defer func() {
+			for range mergedC {
+			}
+		}()
 		for {
 			select {
-			case <-mergedC: // Received change notification
-				changed = true
-			case <-ticker.C: // Periodic check
-				if !changed {
-					changed = false
-					ticker.Reset(time.Second)
+			case <-ctx.Done():
+				return
+			case changed := <-mergedC: // Received change notification
+				// Cache the value delivered by the watcher, it replaces the
+				// previous value of that resource only
+				layers[changed.index].Value = changed.value
+				s.changed(o)
+			case <-s.timerC(): // Debounce period elapsed
+				s.reloaded(o)
+				// Merge cached values and send new configuration
+				config, err := reload[Config](ctx, resources, layers, o)
+				if err != nil {
+					handleError(err)
 					continue
 				}
-				// Load and send new configuration
-				config, err := Load[Config](ctx, resources...)
-				if err != nil {
-					errC <- err
+				changed := Diff(current, config)
+				if len(changed.GetPaths()) == 0 {
 					continue
 				}
-				notifyC <- config
-				changed = false
-				ticker.Reset(time.Second)
+				select {
+				case <-ctx.Done():
+					return
+				case notifyC <- Event[Config]{Old: current, New: config, Changed: changed}:
+					current = config
+				}
 			}
 		}
 	}()
 
-	return stop, nil
+	go w.shutdown(parent, ctx)
+	return w, nil
+}
+
+// reload loads the resources whose value is not cached yet, then merges the
+// cached values into a new configuration. Resources that already delivered
+// a value are not loaded again.
+func reload[Config proto.Message](ctx context.Context, resources []resource.Resource, layers []merge.Layer, o *options) (Config, error) {
+	for i, r := range resources {
+		if layers[i].Value != nil {
+			continue
+		}
+		value, err := r.Load(ctx)
+		if err != nil {
+			var config Config
+			return config, err
+		}
+		layers[i].Value = value
+	}
+	return decode[Config](ctx, resources, layers, o)
+}
+
+// schedule decides when Watch reloads after change notifications,
+// following the debounce, max delay and min interval options.
+type schedule struct {
+	// timer fires when the pending reload is due, nil if nothing is pending
+	timer timer
+	// firstChange is the time of the first change since the last reload
+	firstChange time.Time
+	// lastReload is the time of the last reload
+	lastReload time.Time
+}
+
+// changed records a change notification and reschedules the pending reload.
+func (s *schedule) changed(o *options) {
+	now := o.clock.Now()
+	if s.timer == nil {
+		s.firstChange = now
+	}
+	due := now.Add(o.debounce)
+	if o.maxDelay > 0 && due.After(s.firstChange.Add(o.maxDelay)) {
+		due = s.firstChange.Add(o.maxDelay)
+	}
+	if o.minInterval > 0 && !s.lastReload.IsZero() && due.Before(s.lastReload.Add(o.minInterval)) {
+		due = s.lastReload.Add(o.minInterval)
+	}
+	s.stop()
+	s.timer = o.clock.NewTimer(due.Sub(now))
+}
+
+// reloaded records that the pending reload is being performed.
+func (s *schedule) reloaded(o *options) {
+	s.timer = nil
+	s.lastReload = o.clock.Now()
+}
+
+// timerC returns the channel of the pending reload, nil if nothing is pending.
+func (s *schedule) timerC() <-chan time.Time {
+	if s.timer == nil {
+		return nil
+	}
+	return s.timer.C()
+}
+
+// stop cancels the pending reload.
+func (s *schedule) stop() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
 }
 
 // appendSendChannel converts send-only channels to receive-only channels
@@ -104,15 +314,22 @@ func appendSendChannel[T any](c []<-chan T, channels ...chan T) []<-chan T {
 	return c
 }
 
-// fanIn combines multiple input channels into a single output channel.
+// indexed is a value received by fanIn, tagged with the index of its input channel.
+type indexed[T any] struct {
+	index int
+	value T
+}
+
+// fanIn combines multiple input channels into a single output channel,
+// tagging every value with the index of the channel it was received from.
 // It runs until all input channels are closed or context is cancelled.
 // This is a helper function for Watch.
-func fanIn[T any](ctx context.Context, ins ...<-chan T) <-chan T {
-	out := make(chan T)
+func fanIn[T any](ctx context.Context, ins ...<-chan T) <-chan indexed[T] {
+	out := make(chan indexed[T])
 	var wg sync.WaitGroup
-	for _, ch := range ins {
+	for index, ch := range ins {
 		wg.Add(1)
-		go func(ch <-chan T) {
+		go func(index int, ch <-chan T) {
 			defer wg.Done()
 			for {
 				select {
@@ -125,11 +342,11 @@ func fanIn[T any](ctx context.Context, ins ...<-chan T) <-chan T {
 					select {
 					case <-ctx.Done():
 						return
-					case out <- v:
+					case out <- indexed[T]{index: index, value: v}:
 					}
 				}
 			}
-		}(ch)
+		}(index, ch)
 	}
 	go func() {
 		wg.Wait()
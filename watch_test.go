@@ -3,10 +3,15 @@ package config
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/go-leo/config/resource"
 	"github.com/go-leo/config/test"
+	"go.uber.org/goleak"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -32,7 +37,7 @@ func TestWatch(t *testing.T) {
 		v, _ := structpb.NewStruct(map[string]any{"field1": "value1"})
 
 		// Setup test channels
-		notifyC := make(chan *test.Config, 1)
+		notifyC := make(chan Event[*test.Config], 1)
 		errC := make(chan error, 1)
 
 		// Create mock resource
@@ -48,11 +53,11 @@ func TestWatch(t *testing.T) {
 		}
 
 		// Call Watch
-		stop, err := Watch[*test.Config](ctx, notifyC, errC, mockRes)
+		watcher, err := Watch[*test.Config](ctx, notifyC, errC, []resource.Resource{mockRes})
 		if err != nil {
 			t.Fatalf("Watch failed: %v", err)
 		}
-		defer stop(ctx)
+		defer watcher.Close(ctx)
 
 		// Test notification
 		select {
@@ -65,7 +70,7 @@ func TestWatch(t *testing.T) {
 
 	t.Run("WatchError", func(t *testing.T) {
 		ctx := context.Background()
-		notifyC := make(chan *test.Config, 1)
+		notifyC := make(chan Event[*test.Config], 1)
 		errC := make(chan error, 1)
 
 		expectedErr := errors.New("watch error")
@@ -75,7 +80,7 @@ func TestWatch(t *testing.T) {
 			},
 		}
 
-		_, err := Watch[*test.Config](ctx, notifyC, errC, mockRes)
+		_, err := Watch[*test.Config](ctx, notifyC, errC, []resource.Resource{mockRes})
 		if err == nil || !errors.Is(err, expectedErr) {
 			t.Errorf("Expected error %v, got %v", expectedErr, err)
 		}
@@ -83,7 +88,7 @@ func TestWatch(t *testing.T) {
 
 	t.Run("ContextCancel", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
-		notifyC := make(chan *test.Config, 1)
+		notifyC := make(chan Event[*test.Config], 1)
 		errC := make(chan error, 1)
 
 		mockRes := &mockResource{
@@ -92,19 +97,26 @@ func TestWatch(t *testing.T) {
 			},
 		}
 
-		_, err := Watch[*test.Config](ctx, notifyC, errC, mockRes)
+		watcher, err := Watch[*test.Config](ctx, notifyC, errC, []resource.Resource{mockRes})
 		if err != nil {
 			t.Fatalf("Watch failed: %v", err)
 		}
 
 		// Cancel context and verify behavior
 		cancel()
-		time.Sleep(100 * time.Millisecond) // Allow goroutines to exit
+		select {
+		case <-watcher.Done():
+		case <-time.After(time.Second):
+			t.Fatal("Timeout waiting for watcher to shut down")
+		}
+		if !errors.Is(watcher.Err(), context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", watcher.Err())
+		}
 	})
 
 	t.Run("StopAllResources", func(t *testing.T) {
 		ctx := context.Background()
-		notifyC := make(chan *test.Config, 1)
+		notifyC := make(chan Event[*test.Config], 1)
 		errC := make(chan error, 1)
 
 		stopCalled := false
@@ -117,12 +129,12 @@ func TestWatch(t *testing.T) {
 			},
 		}
 
-		stop, err := Watch[*test.Config](ctx, notifyC, errC, mockRes)
+		watcher, err := Watch[*test.Config](ctx, notifyC, errC, []resource.Resource{mockRes})
 		if err != nil {
 			t.Fatalf("Watch failed: %v", err)
 		}
 
-		if err := stop(ctx); err != nil {
+		if err := watcher.Close(ctx); err != nil {
 			t.Errorf("Stop failed: %v", err)
 		}
 
@@ -132,6 +144,212 @@ func TestWatch(t *testing.T) {
 	})
 }
 
+func TestWatchOptions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	v, _ := structpb.NewStruct(map[string]any{"field1": "value1"})
+	notifyC := make(chan Event[*test.Config], 1)
+
+	expectedErr := errors.New("load error")
+	handledC := make(chan error, 1)
+	changedRes := &mockResource{
+		watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+			notifyC <- v
+			return func(ctx context.Context) error { return nil }, nil
+		},
+	}
+	// not cached yet, loaded by the first reload
+	failingRes := &mockResource{
+		watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+			return func(ctx context.Context) error { return nil }, nil
+		},
+		loadFunc: func(ctx context.Context) (*structpb.Struct, error) {
+			return nil, expectedErr
+		},
+	}
+
+	// errC is nil, errors must go to the handler only
+	watcher, err := Watch[*test.Config](ctx, notifyC, nil, []resource.Resource{changedRes, failingRes},
+		WithDebounce(10*time.Millisecond),
+		WithErrorHandler(func(err error) { handledC <- err }),
+	)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer watcher.Close(ctx)
+
+	select {
+	case err := <-handledC:
+		if !errors.Is(err, expectedErr) {
+			t.Errorf("Expected error %v, got %v", expectedErr, err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Error("Timeout waiting for handled error")
+	}
+}
+
+func TestWatchIncremental(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notifyC := make(chan Event[*test.Config], 1)
+	changeC := make(chan *structpb.Struct)
+	loads := make(map[string]int)
+	var loadsMutex sync.Mutex
+	newResource := func(name string, value map[string]any, changing bool) *mockResource {
+		v, _ := structpb.NewStruct(value)
+		return &mockResource{
+			watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+				if changing {
+					go func() {
+						for v := range changeC {
+							notifyC <- v
+						}
+					}()
+				}
+				return func(ctx context.Context) error { return nil }, nil
+			},
+			loadFunc: func(ctx context.Context) (*structpb.Struct, error) {
+				loadsMutex.Lock()
+				loads[name]++
+				loadsMutex.Unlock()
+				return v, nil
+			},
+		}
+	}
+	fileRes := newResource("file", map[string]any{"field1": "file"}, true)
+	remoteRes := newResource("remote", map[string]any{"field2": "remote"}, false)
+	resources := []resource.Resource{fileRes, remoteRes}
+
+	// Watch starts from the values of the initial load
+	_, layers, err := load[*test.Config](ctx, resources, newOptions())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	loadsMutex.Lock()
+	loads = make(map[string]int)
+	loadsMutex.Unlock()
+
+	watcher, err := Watch[*test.Config](ctx, notifyC, make(chan error, 1), resources,
+		withLayers(layers), WithDebounce(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer watcher.Close(ctx)
+	defer close(changeC)
+
+	var previous *test.Config
+	for _, value := range []string{"v1", "v2", "v3"} {
+		v, _ := structpb.NewStruct(map[string]any{"field1": value})
+		changeC <- v
+		select {
+		case event := <-notifyC:
+			if conf := event.New; conf.GetField1() != value || conf.GetField2() != "remote" {
+				t.Errorf("Expected %s and remote, got %v", value, conf)
+			}
+			if event.Old != previous {
+				t.Errorf("Expected old config %v, got %v", previous, event.Old)
+			}
+			if previous != nil && !reflect.DeepEqual([]string{"field1"}, event.Changed.GetPaths()) {
+				t.Errorf("Expected [field1] to change, got %v", event.Changed.GetPaths())
+			}
+			previous = event.New
+		case <-time.After(time.Second):
+			t.Fatal("Timeout waiting for notification")
+		}
+	}
+
+	// a reload that changes nothing is not sent
+	v, _ := structpb.NewStruct(map[string]any{"field1": "v3"})
+	changeC <- v
+	select {
+	case event := <-notifyC:
+		t.Errorf("Unexpected notification %v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	loadsMutex.Lock()
+	defer loadsMutex.Unlock()
+	if loads["file"] != 0 {
+		t.Errorf("Expected changed resource not to be loaded, got %d loads", loads["file"])
+	}
+	if loads["remote"] != 0 {
+		t.Errorf("Expected unchanged resource not to be loaded again, got %d loads", loads["remote"])
+	}
+}
+
+func TestWatchValidation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notifyC := make(chan Event[*test.Config], 1)
+	errC := make(chan error, 1)
+	changeC := make(chan *structpb.Struct)
+	res := &mockResource{
+		watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+			go func() {
+				for v := range changeC {
+					notifyC <- v
+				}
+			}()
+			return func(ctx context.Context) error { return nil }, nil
+		},
+	}
+	invalid := errors.New("field1 is required")
+	watcher, err := Watch[*test.Config](ctx, notifyC, errC, []resource.Resource{res},
+		WithDebounce(time.Millisecond),
+		WithValidator(func(c *test.Config) error {
+			if c.GetField1() == "" {
+				return invalid
+			}
+			return nil
+		}))
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer watcher.Close(ctx)
+	defer close(changeC)
+
+	valid, _ := structpb.NewStruct(map[string]any{"field1": "v1"})
+	changeC <- valid
+	var current *test.Config
+	select {
+	case event := <-notifyC:
+		current = event.New
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for notification")
+	}
+
+	// an invalid reload is reported and the previous config stays active
+	broken, _ := structpb.NewStruct(map[string]any{"field2": "v2"})
+	changeC <- broken
+	select {
+	case err := <-errC:
+		if !errors.Is(err, invalid) {
+			t.Errorf("Expected error %v, got %v", invalid, err)
+		}
+	case event := <-notifyC:
+		t.Fatalf("Unexpected notification %v", event)
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for validation error")
+	}
+
+	fixed, _ := structpb.NewStruct(map[string]any{"field1": "v3"})
+	changeC <- fixed
+	select {
+	case event := <-notifyC:
+		if event.Old != current {
+			t.Errorf("Expected old config %v, got %v", current, event.Old)
+		}
+		if event.New.GetField1() != "v3" {
+			t.Errorf("Expected v3, got %v", event.New)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for notification")
+	}
+}
+
 func TestAppendSendChannel(t *testing.T) {
 	inCh := make(chan int)
 	outCh := appendSendChannel([]<-chan int{}, inCh)
@@ -157,7 +375,10 @@ func TestFanIn(t *testing.T) {
 	for i := 0; i < 2; i++ {
 		select {
 		case v := <-out:
-			results[v] = true
+			if v.value != v.index+1 {
+				t.Errorf("Expected value %d from channel %d, got %d", v.index+1, v.index, v.value)
+			}
+			results[v.value] = true
 		case <-time.After(time.Second):
 			t.Fatal("Timeout waiting for fan-in results")
 		}
@@ -167,3 +388,262 @@ func TestFanIn(t *testing.T) {
 		t.Error("Did not receive all expected values")
 	}
 }
+
+// fakeClock implements clock with manually advanced time
+type fakeClock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+	// createdC receives a value every time a timer is created
+	createdC chan struct{}
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0), createdC: make(chan struct{}, 16)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) timer {
+	c.mutex.Lock()
+	t := &fakeTimer{clock: c, due: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, t)
+	c.mutex.Unlock()
+	c.createdC <- struct{}{}
+	return t
+}
+
+// Advance moves the time forward and fires the timers that are due
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+	timers := c.timers[:0]
+	for _, t := range c.timers {
+		if t.stopped {
+			continue
+		}
+		if !t.due.After(c.now) {
+			t.c <- c.now
+			continue
+		}
+		timers = append(timers, t)
+	}
+	c.timers = timers
+}
+
+// fakeTimer is a timer created by fakeClock
+type fakeTimer struct {
+	clock *fakeClock
+	due   time.Time
+	c     chan time.Time
+	// stopped is guarded by the mutex of clock
+	stopped bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	t.stopped = true
+	return true
+}
+
+// scheduleHarness drives Watch with a fake clock and a resource whose changes are triggered by the test
+type scheduleHarness struct {
+	t       *testing.T
+	clock   *fakeClock
+	changeC chan<- *structpb.Struct
+	notifyC chan Event[*test.Config]
+	value   *structpb.Struct
+	changes int
+}
+
+func newScheduleHarness(t *testing.T, opts ...Option) *scheduleHarness {
+	h := &scheduleHarness{t: t, clock: newFakeClock(), notifyC: make(chan Event[*test.Config], 16)}
+	h.value, _ = structpb.NewStruct(map[string]any{"field1": "value1"})
+	ready := make(chan struct{})
+	mockRes := &mockResource{
+		watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+			h.changeC = notifyC
+			close(ready)
+			return func(ctx context.Context) error { return nil }, nil
+		},
+		loadFunc: func(ctx context.Context) (*structpb.Struct, error) {
+			return h.value, nil
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	watcher, err := Watch[*test.Config](ctx, h.notifyC, make(chan error, 16), []resource.Resource{mockRes}, append(opts, withClock(h.clock))...)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	t.Cleanup(func() { watcher.Close(ctx) })
+	<-ready
+	return h
+}
+
+// change sends a new value and waits until Watch scheduled a reload
+func (h *scheduleHarness) change() {
+	h.changes++
+	value, _ := structpb.NewStruct(map[string]any{"field1": fmt.Sprint("value", h.changes)})
+	h.changeC <- value
+	select {
+	case <-h.clock.createdC:
+	case <-time.After(time.Second):
+		h.t.Fatal("Timeout waiting for reload to be scheduled")
+	}
+}
+
+// expectReload advances the clock and checks whether a reload happened
+func (h *scheduleHarness) expectReload(d time.Duration, reload bool) {
+	h.t.Helper()
+	h.clock.Advance(d)
+	select {
+	case <-h.notifyC:
+		if !reload {
+			h.t.Fatalf("Unexpected reload at %v", h.clock.Now())
+		}
+	case <-time.After(50 * time.Millisecond):
+		if reload {
+			h.t.Fatalf("Expected reload at %v", h.clock.Now())
+		}
+	}
+}
+
+func TestWatchSchedule(t *testing.T) {
+	t.Run("Debounce", func(t *testing.T) {
+		h := newScheduleHarness(t, WithDebounce(100*time.Millisecond))
+		h.change()
+		h.expectReload(60*time.Millisecond, false)
+		h.change()
+		h.expectReload(60*time.Millisecond, false)
+		h.change()
+		h.expectReload(60*time.Millisecond, false)
+		// quiet period elapsed since the last change
+		h.expectReload(40*time.Millisecond, true)
+		// the burst is coalesced into a single reload
+		h.expectReload(time.Second, false)
+	})
+
+	t.Run("MaxDelay", func(t *testing.T) {
+		h := newScheduleHarness(t, WithDebounce(100*time.Millisecond), WithMaxDelay(150*time.Millisecond))
+		h.change()
+		h.expectReload(80*time.Millisecond, false)
+		h.change()
+		// debounce would fire at 180ms, max delay forces it at 150ms
+		h.expectReload(70*time.Millisecond, true)
+	})
+
+	t.Run("MinInterval", func(t *testing.T) {
+		h := newScheduleHarness(t, WithDebounce(10*time.Millisecond), WithMinInterval(time.Second))
+		h.change()
+		h.expectReload(10*time.Millisecond, true)
+		h.change()
+		h.expectReload(10*time.Millisecond, false)
+		h.expectReload(900*time.Millisecond, false)
+		// one second after the first reload
+		h.expectReload(90*time.Millisecond, true)
+	})
+}
+
+// tickingResource notifies a change every few milliseconds until stopped
+type tickingResource struct {
+	value *structpb.Struct
+	err   error
+}
+
+func (r *tickingResource) Load(ctx context.Context) (*structpb.Struct, error) {
+	return r.value, r.err
+}
+
+func (r *tickingResource) Watch(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+	stopC := make(chan struct{})
+	doneC := make(chan struct{})
+	go func() {
+		defer close(doneC)
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				select {
+				case <-stopC:
+					return
+				case notifyC <- r.value:
+				}
+			}
+		}
+	}()
+	return func(ctx context.Context) error {
+		close(stopC)
+		<-doneC
+		return nil
+	}, nil
+}
+
+func TestWatcherNoLeak(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	v, _ := structpb.NewStruct(map[string]any{"field1": "value1"})
+	tests := []struct {
+		name      string
+		resources []resource.Resource
+	}{
+		// nobody reads notifyC, the reload loop blocks sending the config
+		{name: "BlockedNotify", resources: []resource.Resource{&tickingResource{value: v}, &tickingResource{value: v}}},
+		// nobody reads errC, the reload loop blocks sending the error
+		{name: "BlockedError", resources: []resource.Resource{&tickingResource{value: v, err: errors.New("load error")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcher, err := Watch[*test.Config](context.Background(), make(chan Event[*test.Config]), make(chan error), tt.resources,
+				WithDebounce(time.Millisecond), WithMaxDelay(time.Millisecond))
+			if err != nil {
+				t.Fatalf("Watch failed: %v", err)
+			}
+			// let the reload loop block on a send
+			time.Sleep(50 * time.Millisecond)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if err := watcher.Close(ctx); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+			select {
+			case <-watcher.Done():
+			default:
+				t.Error("Expected Done to be closed after Close")
+			}
+			if !errors.Is(watcher.Err(), ErrClosed) {
+				t.Errorf("Expected ErrClosed, got %v", watcher.Err())
+			}
+		})
+	}
+}
+
+func TestWatcherStartFailureNoLeak(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	v, _ := structpb.NewStruct(map[string]any{"field1": "value1"})
+	expectedErr := errors.New("watch error")
+	failing := &mockResource{
+		watchFunc: func(ctx context.Context, notifyC chan<- *structpb.Struct, errC chan<- error) (func(context.Context) error, error) {
+			return nil, expectedErr
+		},
+	}
+	_, err := Watch[*test.Config](context.Background(), make(chan Event[*test.Config]), make(chan error), []resource.Resource{&tickingResource{value: v}, failing})
+	if !errors.Is(err, expectedErr) {
+		t.Errorf("Expected error %v, got %v", expectedErr, err)
+	}
+}